@@ -44,6 +44,7 @@ func main() {
 	// Initialize handlers
 	cardHandler := handlers.NewCardHandler()
 	deckHandler := handlers.NewDeckHandler()
+	gameHandler := handlers.NewGameHandler()
 
 	// API routes
 	api := r.Group("/api/v1")
@@ -69,6 +70,23 @@ func main() {
 			decks.DELETE("/:id", deckHandler.DeleteDeck)
 			decks.POST("/validate", deckHandler.ValidateDeck)
 		}
+
+		// Game routes
+		games := api.Group("/games")
+		{
+			games.POST("", gameHandler.CreateGame)
+			games.GET("/:id", gameHandler.GetGame)
+			games.GET("/:id/actions", gameHandler.GetLegalActions)
+			games.POST("/:id/mulligan", gameHandler.Mulligan)
+			games.POST("/:id/play", gameHandler.PlayCard)
+			games.POST("/:id/ability", gameHandler.ActivateAbility)
+			games.POST("/:id/attack", gameHandler.Attack)
+			games.POST("/:id/block", gameHandler.Block)
+			games.POST("/:id/pass", gameHandler.Pass)
+			games.POST("/:id/phase", gameHandler.ChangePhase)
+			games.POST("/:id/phase/choice", gameHandler.ResolvePhaseChoice)
+			games.POST("/:id/choice", gameHandler.ResolveEffectChoice)
+		}
 	}
 
 	// Start server
@@ -2,23 +2,38 @@ package effects
 
 import (
 	"fmt"
-	"github.com/your-org/card_game/backend/internal/models"
+	"mememe-tcg/internal/models"
 )
 
 // BaseEffect provides common functionality for effects
 type BaseEffect struct {
-	Trigger     TriggerType
-	Description string
+	Trigger         TriggerType
+	Description     string
+	Optional        bool // "できる": the controller may decline the effect when it resolves
+	AcceptByDefault bool // Whether an optional effect is used if the controller does not answer in time
 }
 
 func (e *BaseEffect) GetTrigger() TriggerType {
 	return e.Trigger
 }
 
+func (e *BaseEffect) IsOptional() bool {
+	return e.Optional
+}
+
+func (e *BaseEffect) AcceptsByDefault() bool {
+	return e.AcceptByDefault
+}
+
 func (e *BaseEffect) GetDescription() string {
 	return e.Description
 }
 
+// GetTargetSpec describes no targets. Effects that pick targets override it.
+func (e *BaseEffect) GetTargetSpec() TargetSpec {
+	return TargetSpec{}
+}
+
 // PowerModifierEffect modifies the power of friends
 type PowerModifierEffect struct {
 	BaseEffect
@@ -35,6 +50,10 @@ func (e *PowerModifierEffect) CanActivate(game *GameContext, source *models.Card
 	return true
 }
 
+func (e *PowerModifierEffect) ConditionHolds(game *GameContext, source *models.Card) bool {
+	return e.Condition == nil || e.Condition(game, source)
+}
+
 func (e *PowerModifierEffect) GetTargets(game *GameContext, source *models.Card) []Target {
 	var targets []Target
 	playerState := game.GetPlayerState(game.ActivePlayer)
@@ -42,47 +61,49 @@ func (e *PowerModifierEffect) GetTargets(game *GameContext, source *models.Card)
 	switch e.Scope {
 	case ScopeSelf:
 		// Target only the source friend
-		for pos, friend := range playerState.BattleArea {
-			if friend.CardNo == source.CardNo {
-				targets = append(targets, Target{
-					Type:     "friend",
-					ID:       friend.CardNo,
-					Location: fmt.Sprintf("battle_area_%d_%s", game.ActivePlayer, pos),
-				})
+		for _, pos := range SortedPositions(playerState.BattleArea) {
+			friend := playerState.BattleArea[pos]
+			if friend.InstanceID == game.SourceID {
+				targets = append(targets, FriendTarget(game.ActivePlayer, pos, friend))
 			}
 		}
 	case ScopeMyFriends:
 		// Target all of the player's friends
-		for pos, friend := range playerState.BattleArea {
-			targets = append(targets, Target{
-				Type:     "friend",
-				ID:       friend.CardNo,
-				Location: fmt.Sprintf("battle_area_%d_%s", game.ActivePlayer, pos),
-			})
+		for _, pos := range SortedPositions(playerState.BattleArea) {
+			friend := playerState.BattleArea[pos]
+			targets = append(targets, FriendTarget(game.ActivePlayer, pos, friend))
 		}
 	case ScopeOppFriends:
 		// Target all opponent's friends
 		oppPlayer := game.GetOpponentPlayer(game.ActivePlayer)
 		oppState := game.GetPlayerState(oppPlayer)
-		for pos, friend := range oppState.BattleArea {
-			targets = append(targets, Target{
-				Type:     "friend",
-				ID:       friend.CardNo,
-				Location: fmt.Sprintf("battle_area_%d_%s", oppPlayer, pos),
-			})
+		for _, pos := range SortedPositions(oppState.BattleArea) {
+			friend := oppState.BattleArea[pos]
+			targets = append(targets, FriendTarget(oppPlayer, pos, friend))
 		}
 	}
 	
 	return targets
 }
 
+func (e *PowerModifierEffect) GetTargetSpec() TargetSpec {
+	owner := OwnerSelf
+	if e.Scope == ScopeOppFriends {
+		owner = OwnerOpponent
+	}
+	
+	return TargetSpec{
+		Max:         AllTargets,
+		Types:       []models.TargetType{models.TargetFriend},
+		Zones:       []models.Zone{models.ZoneBattleArea},
+		Owner:       owner,
+		Description: "対象のふれんど",
+	}
+}
+
 func (e *PowerModifierEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
 	for _, target := range targets {
-		// Extract player number from location
-		var player int
-		fmt.Sscanf(target.Location, "battle_area_%d_", &player)
-		
-		if err := game.ModifyPower(player, target.ID, e.Amount); err != nil {
+		if err := game.ModifyPower(target.Player, target.ID, e.Amount, models.EffectDuration(e.Duration)); err != nil {
 			return err
 		}
 	}
@@ -123,23 +144,25 @@ func (e *ReturnToHandEffect) CanActivate(game *GameContext, source *models.Card)
 }
 
 func (e *ReturnToHandEffect) GetTargets(game *GameContext, source *models.Card) []Target {
-	var targets []Target
-	
+	return e.GetTargetSpec().Collect(game)
+}
+
+func (e *ReturnToHandEffect) GetTargetSpec() TargetSpec {
+	spec := TargetSpec{
+		Max:         1,
+		Types:       []models.TargetType{models.TargetFriend},
+		Zones:       []models.Zone{models.ZoneBattleArea},
+		MaxCost:     e.MaxCost,
+		Description: "ふれんど1体を選択",
+	}
+	if e.RequireTarget {
+		spec.Min = 1
+	}
 	if e.Scope == ScopeOppFriends {
-		oppPlayer := game.GetOpponentPlayer(game.ActivePlayer)
-		oppState := game.GetPlayerState(oppPlayer)
-		
-		for pos, friend := range oppState.BattleArea {
-			// TODO: Check friend cost against MaxCost
-			targets = append(targets, Target{
-				Type:     "friend",
-				ID:       friend.CardNo,
-				Location: fmt.Sprintf("battle_area_%d_%s", oppPlayer, pos),
-			})
-		}
+		spec.Owner = OwnerOpponent
+		spec.Description = "相手のふれんど1体を選択"
 	}
-	
-	return targets
+	return spec
 }
 
 func (e *ReturnToHandEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
@@ -148,12 +171,7 @@ func (e *ReturnToHandEffect) Apply(game *GameContext, source *models.Card, targe
 	}
 	
 	for _, target := range targets {
-		// Extract player number from location
-		var player int
-		var pos string
-		fmt.Sscanf(target.Location, "battle_area_%d_%s", &player, &pos)
-		
-		if err := game.ReturnToHand(player, target.ID); err != nil {
+		if err := game.ReturnToHand(target.Player, target.ID); err != nil {
 			return err
 		}
 	}
@@ -174,23 +192,19 @@ func (e *DestroyFriendEffect) CanActivate(game *GameContext, source *models.Card
 }
 
 func (e *DestroyFriendEffect) GetTargets(game *GameContext, source *models.Card) []Target {
-	var targets []Target
-	
-	oppPlayer := game.GetOpponentPlayer(game.ActivePlayer)
-	oppState := game.GetPlayerState(oppPlayer)
-	
-	for pos, friend := range oppState.BattleArea {
-		if friend.Power <= e.MaxPower {
-			targets = append(targets, Target{
-				Type:     "friend",
-				ID:       friend.CardNo,
-				Location: fmt.Sprintf("battle_area_%d_%s", oppPlayer, pos),
-				Data:     friend,
-			})
-		}
+	return e.GetTargetSpec().Collect(game)
+}
+
+func (e *DestroyFriendEffect) GetTargetSpec() TargetSpec {
+	return TargetSpec{
+		Min:         1,
+		Max:         1,
+		Types:       []models.TargetType{models.TargetFriend},
+		Zones:       []models.Zone{models.ZoneBattleArea},
+		Owner:       OwnerOpponent,
+		MaxPower:    e.MaxPower,
+		Description: "相手のふれんど1体を選択",
 	}
-	
-	return targets
 }
 
 func (e *DestroyFriendEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
@@ -199,11 +213,7 @@ func (e *DestroyFriendEffect) Apply(game *GameContext, source *models.Card, targ
 	}
 	
 	for _, target := range targets {
-		// Extract player number from location
-		var player int
-		fmt.Sscanf(target.Location, "battle_area_%d_", &player)
-		
-		if err := game.DestroyFriend(player, target.ID); err != nil {
+		if err := game.DestroyFriend(target.Player, target.ID); err != nil {
 			return err
 		}
 	}
@@ -220,7 +230,7 @@ type RevealNegativeEnergyEffect struct {
 func (e *RevealNegativeEnergyEffect) CanActivate(game *GameContext, source *models.Card) bool {
 	playerState := game.GetPlayerState(game.ActivePlayer)
 	// Check if there are unrevealed cards in negative energy
-	return len(playerState.NegativeEnergy) > 0
+	return playerState.NegativeEnergy.FaceDownCount() > 0
 }
 
 func (e *RevealNegativeEnergyEffect) GetTargets(game *GameContext, source *models.Card) []Target {
@@ -250,26 +260,20 @@ func (e *RestFriendEffect) GetTargets(game *GameContext, source *models.Card) []
 		oppPlayer := game.GetOpponentPlayer(game.ActivePlayer)
 		oppState := game.GetPlayerState(oppPlayer)
 		
-		for pos, friend := range oppState.BattleArea {
+		for _, pos := range SortedPositions(oppState.BattleArea) {
+			friend := oppState.BattleArea[pos]
 			if !friend.IsRest {
-				targets = append(targets, Target{
-					Type:     "friend",
-					ID:       friend.CardNo,
-					Location: fmt.Sprintf("battle_area_%d_%s", oppPlayer, pos),
-				})
+				targets = append(targets, FriendTarget(oppPlayer, pos, friend))
 			}
 		}
 	} else if e.Action == "active" {
 		// Target own rested friends
 		playerState := game.GetPlayerState(game.ActivePlayer)
 		
-		for pos, friend := range playerState.BattleArea {
+		for _, pos := range SortedPositions(playerState.BattleArea) {
+			friend := playerState.BattleArea[pos]
 			if friend.IsRest {
-				targets = append(targets, Target{
-					Type:     "friend",
-					ID:       friend.CardNo,
-					Location: fmt.Sprintf("battle_area_%d_%s", game.ActivePlayer, pos),
-				})
+				targets = append(targets, FriendTarget(game.ActivePlayer, pos, friend))
 			}
 		}
 	}
@@ -277,18 +281,30 @@ func (e *RestFriendEffect) GetTargets(game *GameContext, source *models.Card) []
 	return targets
 }
 
+func (e *RestFriendEffect) GetTargetSpec() TargetSpec {
+	spec := TargetSpec{
+		Min:         1,
+		Max:         1,
+		Types:       []models.TargetType{models.TargetFriend},
+		Zones:       []models.Zone{models.ZoneBattleArea},
+		Owner:       OwnerOpponent,
+		Description: "相手のふれんど1体を選択",
+	}
+	if e.Action == "active" {
+		spec.Owner = OwnerSelf
+		spec.Description = "自分のふれんど1体を選択"
+	}
+	return spec
+}
+
 func (e *RestFriendEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
 	for _, target := range targets {
-		// Extract player number from location
-		var player int
-		fmt.Sscanf(target.Location, "battle_area_%d_", &player)
-		
 		if e.Action == "rest" {
-			if err := game.RestFriend(player, target.ID); err != nil {
+			if err := game.RestFriend(target.Player, target.ID); err != nil {
 				return err
 			}
 		} else if e.Action == "active" {
-			if err := game.ActiveFriend(player, target.ID); err != nil {
+			if err := game.ActiveFriend(target.Player, target.ID); err != nil {
 				return err
 			}
 		}
@@ -1,7 +1,7 @@
 package effects
 
 import (
-	"github.com/your-org/card_game/backend/internal/models"
+	"mememe-tcg/internal/models"
 )
 
 // Field card specific effects
@@ -17,7 +17,7 @@ func NewMiharashidaiEffect() Effect {
 		Scope:  ScopeMyFriends,
 		Condition: func(game *GameContext, source *models.Card) bool {
 			// Only active during your turn
-			if game.ActivePlayer != game.ActivePlayer {
+			if game.Game.ActivePlayer != game.ActivePlayer {
 				return false
 			}
 			
@@ -44,8 +44,9 @@ func NewJinjaEffect() Effect {
 func NewMasashiHouseEffect() Effect {
 	return &EndPhaseActivateEffect{
 		BaseEffect: BaseEffect{
-			Trigger:     TriggerEndPhase,
+			Trigger:     TriggerStartPhase, // At the start of the end phase, before the hand limit
 			Description: "エンドフェイズ開始時、自分のふれんど1体をアクティブにできる。",
+			Optional:    true,
 		},
 	}
 }
@@ -160,22 +161,39 @@ func (e *ConditionalPowerBoostEffect) GetTargets(game *GameContext, source *mode
 	var targets []Target
 	playerState := game.GetPlayerState(game.ActivePlayer)
 	
-	for pos, friend := range playerState.BattleArea {
-		targets = append(targets, Target{
-			Type:     "friend",
-			ID:       friend.CardNo,
-			Location: pos,
-		})
+	for _, pos := range SortedPositions(playerState.BattleArea) {
+		friend := playerState.BattleArea[pos]
+		targets = append(targets, FriendTarget(game.ActivePlayer, pos, friend))
 	}
 	
 	return targets
 }
 
+func (e *ConditionalPowerBoostEffect) GetTargetSpec() TargetSpec {
+	return TargetSpec{
+		Max:         AllTargets,
+		Types:       []models.TargetType{models.TargetFriend},
+		Zones:       []models.Zone{models.ZoneBattleArea},
+		Owner:       OwnerSelf,
+		Description: "自分のふれんど",
+	}
+}
+
 func (e *ConditionalPowerBoostEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
 	// This is a persistent effect - power boost is calculated dynamically
 	return nil
 }
 
+func (e *ConditionalPowerBoostEffect) GetPowerModifier(game *GameContext, source *models.Card, friendPlayer int, friend models.Friend, friendCard *models.Card) int {
+	if e.Scope == ScopeMyFriends && friendPlayer != game.ActivePlayer {
+		return 0
+	}
+	if !e.CanActivate(game, source) {
+		return 0
+	}
+	return e.Amount
+}
+
 type EnergyPhaseAlternativeEffect struct {
 	BaseEffect
 }
@@ -217,19 +235,40 @@ func (e *EndPhaseActivateEffect) GetTargets(game *GameContext, source *models.Ca
 	var targets []Target
 	playerState := game.GetPlayerState(game.ActivePlayer)
 	
-	for pos, friend := range playerState.BattleArea {
+	for _, pos := range SortedPositions(playerState.BattleArea) {
+		friend := playerState.BattleArea[pos]
 		if friend.IsRest {
-			targets = append(targets, Target{
-				Type:     "friend",
-				ID:       friend.CardNo,
-				Location: pos,
-			})
+			targets = append(targets, FriendTarget(game.ActivePlayer, pos, friend))
 		}
 	}
 	
 	return targets
 }
 
+func (e *EndPhaseActivateEffect) GetTargetSpec() TargetSpec {
+	return TargetSpec{
+		Min:         1,
+		Max:         1,
+		Types:       []models.TargetType{models.TargetFriend},
+		Zones:       []models.Zone{models.ZoneBattleArea},
+		Owner:       OwnerSelf,
+		Description: "アクティブにするふれんどを選んでください",
+	}
+}
+
+func (e *EndPhaseActivateEffect) NextChoice(game *GameContext, source *models.Card, answers [][]Target) *Choice {
+	if len(answers) > 0 {
+		return nil
+	}
+	return &Choice{
+		Kind:        models.ChoiceKindTarget,
+		Options:     e.GetTargets(game, source),
+		Min:         1,
+		Max:         1,
+		Description: "アクティブにするふれんどを選んでください",
+	}
+}
+
 func (e *EndPhaseActivateEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
 	if len(targets) > 0 {
 		return game.ActiveFriend(game.ActivePlayer, targets[0].ID)
@@ -242,16 +281,29 @@ type DrawPhaseManipulateEffect struct {
 }
 
 func (e *DrawPhaseManipulateEffect) CanActivate(game *GameContext, source *models.Card) bool {
-	return game.Game.CurrentPhase == models.PhaseDraw
+	// Only at the start of your own draw phase
+	if game.Game.CurrentPhase != models.PhaseDraw || game.Game.ActivePlayer != game.ActivePlayer {
+		return false
+	}
+	return len(game.GetPlayerState(game.ActivePlayer).Deck) > 0
 }
 
 func (e *DrawPhaseManipulateEffect) GetTargets(game *GameContext, source *models.Card) []Target {
-	return nil // No targets needed
+	return nil // The position is chosen once the card is revealed
+}
+
+func (e *DrawPhaseManipulateEffect) NextChoice(game *GameContext, source *models.Card, answers [][]Target) *Choice {
+	if len(answers) > 0 {
+		return nil
+	}
+	return deckTopPlacementChoice(game, game.ActivePlayer)
 }
 
 func (e *DrawPhaseManipulateEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
-	// TODO: Implement deck manipulation
-	return nil
+	if len(targets) == 0 {
+		return nil
+	}
+	return placeDeckTop(game, game.ActivePlayer, targets[0])
 }
 
 type OpponentStartActivateEnergyEffect struct {
@@ -287,19 +339,28 @@ func (e *OpponentStartActivateEnergyEffect) GetTargets(game *GameContext, source
 	
 	for i, energy := range playerState.EnergyArea {
 		if energy.IsRest {
-			targets = append(targets, Target{
-				Type:     "energy",
-				ID:       energy.CardNo,
-				Location: string(i),
-			})
+			targets = append(targets, EnergyTarget(game.ActivePlayer, i, energy))
 		}
 	}
 	
 	return targets
 }
 
+func (e *OpponentStartActivateEnergyEffect) GetTargetSpec() TargetSpec {
+	return TargetSpec{
+		Min:         1,
+		Max:         1,
+		Types:       []models.TargetType{models.TargetEnergy},
+		Zones:       []models.Zone{models.ZoneEnergyArea},
+		Owner:       OwnerSelf,
+		Description: "アクティブにするエネルギー1枚を選択",
+	}
+}
+
 func (e *OpponentStartActivateEnergyEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
-	// TODO: Implement activating energy card
+	if len(targets) > 0 {
+		return game.ActiveEnergy(game.ActivePlayer, targets[0].ID)
+	}
 	return nil
 }
 
@@ -365,7 +426,7 @@ type FieldCountPowerBoostEffect struct {
 
 func (e *FieldCountPowerBoostEffect) CanActivate(game *GameContext, source *models.Card) bool {
 	// Only active during your turn
-	return game.ActivePlayer == game.ActivePlayer
+	return game.Game.ActivePlayer == game.ActivePlayer
 }
 
 func (e *FieldCountPowerBoostEffect) GetTargets(game *GameContext, source *models.Card) []Target {
@@ -373,11 +434,20 @@ func (e *FieldCountPowerBoostEffect) GetTargets(game *GameContext, source *model
 }
 
 func (e *FieldCountPowerBoostEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
+	// This is a persistent effect - power boost is calculated dynamically
+	return nil
+}
+
+func (e *FieldCountPowerBoostEffect) GetPowerModifier(game *GameContext, source *models.Card, friendPlayer int, friend models.Friend, friendCard *models.Card) int {
+	if friendPlayer != game.ActivePlayer || !e.CanActivate(game, source) {
+		return 0
+	}
+	
 	// Count other field cards
 	fieldCount := 0
 	
 	playerState := game.GetPlayerState(game.ActivePlayer)
-	if playerState.FieldCard != nil && *playerState.FieldCard != source.CardNo {
+	if playerState.FieldCard != nil && playerState.FieldCard.InstanceID != game.SourceID {
 		fieldCount++
 	}
 	
@@ -387,16 +457,7 @@ func (e *FieldCountPowerBoostEffect) Apply(game *GameContext, source *models.Car
 		fieldCount++
 	}
 	
-	// Apply power boost based on field count
-	powerBoost := fieldCount * e.PowerPerField
-	
-	for _, friend := range playerState.BattleArea {
-		if err := game.ModifyPower(game.ActivePlayer, friend.CardNo, powerBoost); err != nil {
-			return err
-		}
-	}
-	
-	return nil
+	return fieldCount * e.PowerPerField
 }
 
 type OnDamageDrawEffect struct {
@@ -423,7 +484,7 @@ type NegativeEnergyPowerBoostEffect struct {
 
 func (e *NegativeEnergyPowerBoostEffect) CanActivate(game *GameContext, source *models.Card) bool {
 	// Only active during your turn
-	return game.ActivePlayer == game.ActivePlayer
+	return game.Game.ActivePlayer == game.ActivePlayer
 }
 
 func (e *NegativeEnergyPowerBoostEffect) GetTargets(game *GameContext, source *models.Card) []Target {
@@ -431,24 +492,20 @@ func (e *NegativeEnergyPowerBoostEffect) GetTargets(game *GameContext, source *m
 }
 
 func (e *NegativeEnergyPowerBoostEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
-	playerState := game.GetPlayerState(game.ActivePlayer)
-	
-	// Count face-down cards in negative energy
-	// TODO: Need to track which cards are revealed/face-up
-	faceDownCount := 0
-	for range playerState.NegativeEnergy {
-		faceDownCount++ // Placeholder - need to track revealed state
+	// This is a persistent effect - power boost is calculated dynamically
+	return nil
+}
+
+func (e *NegativeEnergyPowerBoostEffect) GetPowerModifier(game *GameContext, source *models.Card, friendPlayer int, friend models.Friend, friendCard *models.Card) int {
+	if friendPlayer != game.ActivePlayer || !e.CanActivate(game, source) {
+		return 0
 	}
-	
-	powerBoost := faceDownCount * e.PowerPerCard
-	
-	// Apply to matching color friends
-	for _, friend := range playerState.BattleArea {
-		// TODO: Need to check friend's color
-		if err := game.ModifyPower(game.ActivePlayer, friend.CardNo, powerBoost); err != nil {
-			return err
-		}
+	if friendCard == nil || friendCard.Color != e.ColorFilter {
+		return 0
 	}
 	
-	return nil
-}
\ No newline at end of file
+	playerState := game.GetPlayerState(game.ActivePlayer)
+	
+	// Count face-down cards in negative energy
+	return playerState.NegativeEnergy.FaceDownCount() * e.PowerPerCard
+}
@@ -37,8 +37,7 @@ func NewHiyakeratopusEffect() Effect {
 			Trigger:     TriggerOnAttack,
 			Description: "このふれんどがアタックした時、自分はデッキから1枚ドローする。",
 		},
-		Amount: 1,
-		Scope:  ScopeSelf,
+		Count: 1,
 	}
 }
 
@@ -49,7 +48,6 @@ func NewBoyEffect() Effect {
 			Trigger:     TriggerOnAttack,
 			Description: "このふれんどがアタックした時、パワー3000以下の相手のふれんど1体を破壊する。",
 		},
-		Scope:         ScopeOppFriends,
 		MaxPower:      3000,
 		RequireTarget: true,
 	}
@@ -62,8 +60,7 @@ func NewPochiEffect() Effect {
 			Trigger:     TriggerOnAttack,
 			Description: "このふれんどがアタックした時、自分はデッキから1枚ドローする。",
 		},
-		Amount: 1,
-		Scope:  ScopeSelf,
+		Count: 1,
 	}
 }
 
@@ -93,9 +90,9 @@ func NewUkkiAttackEffect() Effect {
 		BaseEffect: BaseEffect{
 			Trigger:     TriggerOnAttack,
 			Description: "このふれんどがアタックした時、自分の負のエネルギーエリアのカード1枚を破棄できる。",
+			Optional:    true,
 		},
-		Count:    1,
-		Optional: true,
+		Count: 1,
 	}
 }
 
@@ -105,6 +102,7 @@ func NewKurageboTransformEffect() Effect {
 		BaseEffect: BaseEffect{
 			Trigger:     TriggerOnAttack,
 			Description: "このふれんどがアタックした時、自分の負のエネルギーエリアのカード2枚を裏にすることで、このふれんどをアクティブにする。",
+			Optional:    true, // Paying the cost is up to the player
 		},
 		Count: 2,
 	}
@@ -141,14 +139,7 @@ func NewKurageboEffect() Effect {
 		Amount: 1,
 		Condition: func(game *GameContext, source *models.Card) bool {
 			playerState := game.GetPlayerState(game.ActivePlayer)
-			// TODO: Count revealed cards in negative energy
-			revealedCount := 0
-			for _, cardNo := range playerState.NegativeEnergy {
-				// Need to track which cards are revealed
-				_ = cardNo
-				revealedCount++
-			}
-			return revealedCount >= 3
+			return playerState.NegativeEnergy.FaceUpCount() >= 3
 		},
 	}
 }
@@ -246,10 +237,15 @@ func (e *CanAttackImmediatelyEffect) GetTargets(game *GameContext, source *model
 
 func (e *CanAttackImmediatelyEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
 	// This is a persistent effect that modifies game rules
-	// It should be checked when determining if a friend can attack
+	// It is checked through GrantsCapability when validating an attack
 	return nil
 }
 
+func (e *CanAttackImmediatelyEffect) GrantsCapability(game *GameContext, source *models.Card, friendPlayer int, friend models.Friend, capability Capability) bool {
+	// Only this friend itself can attack on the turn it was played
+	return capability == CapabilityAttackImmediately && friendPlayer == game.ActivePlayer && friend.InstanceID == game.SourceID
+}
+
 type DamageModifierEffect struct {
 	BaseEffect
 	Amount    int
@@ -279,32 +275,32 @@ type ReturnSupportFromTrashEffect struct {
 }
 
 func (e *ReturnSupportFromTrashEffect) CanActivate(game *GameContext, source *models.Card) bool {
-	playerState := game.GetPlayerState(game.ActivePlayer)
 	// Check if there are support cards in trash
-	return len(playerState.Trash) > 0 // TODO: Filter for support cards with cost <= MaxCost
+	return len(e.GetTargets(game, source)) > 0
 }
 
 func (e *ReturnSupportFromTrashEffect) GetTargets(game *GameContext, source *models.Card) []Target {
-	var targets []Target
-	playerState := game.GetPlayerState(game.ActivePlayer)
-	
-	// TODO: Need to load card data to check type and cost
-	for _, cardNo := range playerState.Trash {
-		// Check if card is support and cost <= MaxCost
-		targets = append(targets, Target{
-			Type:     "card",
-			ID:       cardNo,
-			Location: "trash",
-		})
+	return e.GetTargetSpec().Collect(game)
+}
+
+func (e *ReturnSupportFromTrashEffect) GetTargetSpec() TargetSpec {
+	return TargetSpec{
+		Max:         1,
+		Types:       []models.TargetType{models.TargetCard},
+		Zones:       []models.Zone{models.ZoneTrash},
+		Owner:       OwnerSelf,
+		CardTypes:   []models.CardType{models.CardTypeSupport},
+		MaxCost:     e.MaxCost,
+		Description: "トラッシュのサポートカード1枚を選択",
 	}
-	
-	return targets
 }
 
 func (e *ReturnSupportFromTrashEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
 	// Move selected card from trash to hand
-	// TODO: Implement
-	return nil
+	if len(targets) == 0 {
+		return nil
+	}
+	return game.AddToHand(game.ActivePlayer, targets[0].ID, models.ZoneTrash)
 }
 
 type ActivateOnBlockEffect struct {
@@ -317,15 +313,22 @@ func (e *ActivateOnBlockEffect) CanActivate(game *GameContext, source *models.Ca
 
 func (e *ActivateOnBlockEffect) GetTargets(game *GameContext, source *models.Card) []Target {
 	// Target is always the blocking friend itself
-	return []Target{{
-		Type:     "friend",
-		ID:       source.CardNo,
-		Location: "self",
-	}}
+	return []Target{SelfTarget(game, source)}
+}
+
+func (e *ActivateOnBlockEffect) GetTargetSpec() TargetSpec {
+	return TargetSpec{
+		Min:         1,
+		Max:         1,
+		Types:       []models.TargetType{models.TargetFriend},
+		Zones:       []models.Zone{models.ZoneBattleArea},
+		Owner:       OwnerSelf,
+		Description: "このふれんど",
+	}
 }
 
 func (e *ActivateOnBlockEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
-	return game.ActiveFriend(game.ActivePlayer, source.CardNo)
+	return game.ActiveFriend(game.ActivePlayer, game.SourceID)
 }
 
 type PlayFieldCardEffect struct {
@@ -335,26 +338,24 @@ type PlayFieldCardEffect struct {
 }
 
 func (e *PlayFieldCardEffect) CanActivate(game *GameContext, source *models.Card) bool {
-	playerState := game.GetPlayerState(game.ActivePlayer)
 	// Check if player has field cards in hand
-	return len(playerState.Hand) > 0 // TODO: Filter for field cards with cost <= MaxCost
+	return len(e.GetTargets(game, source)) > 0
 }
 
 func (e *PlayFieldCardEffect) GetTargets(game *GameContext, source *models.Card) []Target {
-	var targets []Target
-	playerState := game.GetPlayerState(game.ActivePlayer)
-	
-	// TODO: Need to load card data to check type and cost
-	for _, cardNo := range playerState.Hand {
-		// Check if card is field and cost <= MaxCost
-		targets = append(targets, Target{
-			Type:     "card",
-			ID:       cardNo,
-			Location: "hand",
-		})
+	return e.GetTargetSpec().Collect(game)
+}
+
+func (e *PlayFieldCardEffect) GetTargetSpec() TargetSpec {
+	return TargetSpec{
+		Max:         1,
+		Types:       []models.TargetType{models.TargetCard},
+		Zones:       []models.Zone{models.ZoneHand},
+		Owner:       OwnerSelf,
+		CardTypes:   []models.CardType{models.CardTypeField},
+		MaxCost:     e.MaxCost,
+		Description: "手札のフィールドカード1枚を選択",
 	}
-	
-	return targets
 }
 
 func (e *PlayFieldCardEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
@@ -378,23 +379,63 @@ func (e *ReturnToCardsToDeckEffect) GetTargets(game *GameContext, source *models
 	var targets []Target
 	playerState := game.GetPlayerState(game.ActivePlayer)
 	
-	for _, cardNo := range playerState.Trash {
-		targets = append(targets, Target{
-			Type:     "card",
-			ID:       cardNo,
-			Location: "trash",
-		})
+	for _, card := range playerState.Trash {
+		targets = append(targets, CardTarget(game.ActivePlayer, models.ZoneTrash, card))
 	}
 	
 	return targets
 }
 
+func (e *ReturnToCardsToDeckEffect) GetTargetSpec() TargetSpec {
+	return TargetSpec{
+		Max:         e.Count,
+		Types:       []models.TargetType{models.TargetCard},
+		Zones:       []models.Zone{models.ZoneTrash},
+		Owner:       OwnerSelf,
+		Description: "トラッシュのカードを選択",
+	}
+}
+
+// NextChoice picks one trash card at a time and asks where in the deck it goes,
+// until Count cards are placed or the player stops picking
+func (e *ReturnToCardsToDeckEffect) NextChoice(game *GameContext, source *models.Card, answers [][]Target) *Choice {
+	if len(answers)%2 == 1 {
+		card := Picked(answers, len(answers)-1)
+		if len(card) == 0 {
+			return nil
+		}
+		return deckPlacementChoice(game.ActivePlayer, card[0].CardNo, "選んだカード（"+card[0].CardNo+"）をデッキの上か下に置いてください")
+	}
+	if len(answers)/2 >= e.Count {
+		return nil
+	}
+	
+	picked := make(map[string]bool)
+	for i := 0; i < len(answers); i += 2 {
+		for _, card := range answers[i] {
+			picked[card.ID] = true
+		}
+	}
+	var options []Target
+	for _, card := range e.GetTargets(game, source) {
+		if !picked[card.ID] {
+			options = append(options, card)
+		}
+	}
+	return &Choice{
+		Kind:        models.ChoiceKindTarget,
+		Options:     options,
+		Min:         0,
+		Max:         1,
+		Description: "デッキに戻すトラッシュのカードを選べます",
+	}
+}
+
 func (e *ReturnToCardsToDeckEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
-	// Player chooses position (top or bottom) for each card
-	for _, target := range targets {
-		// TODO: Get player choice for position
-		position := "top" // or "bottom"
-		if err := game.MoveCardToDeck(game.ActivePlayer, target.ID, position); err != nil {
+	// Each picked card is followed by the deck position picked for it
+	for i := 0; i+1 < len(targets); i += 2 {
+		position := models.DeckPosition(targets[i+1].ID)
+		if err := game.MoveCardToDeck(game.ActivePlayer, targets[i].ID, position); err != nil {
 			return err
 		}
 	}
@@ -415,10 +456,15 @@ func (e *CanBlockWhileRestedEffect) GetTargets(game *GameContext, source *models
 
 func (e *CanBlockWhileRestedEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
 	// This is a persistent effect that modifies game rules
-	// It should be checked when determining if a friend can block
+	// It is checked through GrantsCapability when validating a block
 	return nil
 }
 
+func (e *CanBlockWhileRestedEffect) GrantsCapability(game *GameContext, source *models.Card, friendPlayer int, friend models.Friend, capability Capability) bool {
+	// Only this friend itself can block while rested
+	return capability == CapabilityBlockWhileRested && friendPlayer == game.ActivePlayer && friend.InstanceID == game.SourceID
+}
+
 // Custom effect types for new attack effects
 
 type RevealAndPlaceDeckTopEffect struct {
@@ -432,17 +478,95 @@ func (e *RevealAndPlaceDeckTopEffect) CanActivate(game *GameContext, source *mod
 
 func (e *RevealAndPlaceDeckTopEffect) GetTargets(game *GameContext, source *models.Card) []Target {
 	// Player chooses whose deck to reveal
-	return []Target{
-		{Type: "deck", ID: "self", Location: "deck"},
-		{Type: "deck", ID: "opponent", Location: "deck"},
+	var targets []Target
+	if len(game.GetPlayerState(game.ActivePlayer).Deck) > 0 {
+		targets = append(targets, deckOption("self", game.ActivePlayer, "自分のデッキ"))
+	}
+	if oppPlayer := game.GetOpponentPlayer(game.ActivePlayer); len(game.GetPlayerState(oppPlayer).Deck) > 0 {
+		targets = append(targets, deckOption("opponent", oppPlayer, "相手のデッキ"))
 	}
+	return targets
 }
 
-func (e *RevealAndPlaceDeckTopEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
-	// Implementation would reveal top card and let player choose placement
+func (e *RevealAndPlaceDeckTopEffect) GetTargetSpec() TargetSpec {
+	return TargetSpec{
+		Min:         1,
+		Max:         1,
+		Types:       []models.TargetType{models.TargetOption},
+		Zones:       []models.Zone{models.ZoneDeck},
+		Description: "オープンするデッキを選んでください",
+	}
+}
+
+func (e *RevealAndPlaceDeckTopEffect) NextChoice(game *GameContext, source *models.Card, answers [][]Target) *Choice {
+	switch len(answers) {
+	case 0:
+		return &Choice{
+			Kind:        models.ChoiceKindOption,
+			Options:     e.GetTargets(game, source),
+			Min:         1,
+			Max:         1,
+			Description: "オープンするデッキを選んでください",
+		}
+	case 1:
+		if deck := Picked(answers, 0); len(deck) > 0 {
+			return deckTopPlacementChoice(game, deck[0].Player)
+		}
+	}
 	return nil
 }
 
+func (e *RevealAndPlaceDeckTopEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
+	if len(targets) < 2 {
+		return nil
+	}
+	return placeDeckTop(game, targets[0].Player, targets[1])
+}
+
+// deckOption is an option that picks a player's deck
+func deckOption(id string, player int, label string) Target {
+	option := OptionTarget(id, label)
+	option.Player = player
+	option.Zone = models.ZoneDeck
+	return option
+}
+
+// deckTopPlacementChoice reveals the top card of a player's deck and asks
+// whether it stays on top or goes to the bottom
+func deckTopPlacementChoice(game *GameContext, player int) *Choice {
+	deck := game.GetPlayerState(player).Deck
+	if len(deck) == 0 {
+		return nil
+	}
+	
+	top := deck[0]
+	return deckPlacementChoice(player, top.CardNo, "オープンしたカード（"+top.CardNo+"）をデッキの上か下に置いてください")
+}
+
+// deckPlacementChoice asks whether a card goes on top of a player's deck or
+// to the bottom. The picked option's ID is the DeckPosition.
+func deckPlacementChoice(player int, cardNo string, description string) *Choice {
+	return &Choice{
+		Kind: models.ChoiceKindOption,
+		Options: []Target{
+			{Type: models.TargetOption, Player: player, Zone: models.ZoneDeck, ID: string(models.DeckTop), CardNo: cardNo, Label: "デッキの上"},
+			{Type: models.TargetOption, Player: player, Zone: models.ZoneDeck, ID: string(models.DeckBottom), CardNo: cardNo, Label: "デッキの下"},
+		},
+		Min:         1,
+		Max:         1,
+		Description: description,
+	}
+}
+
+// placeDeckTop moves the top card of a player's deck to the picked position
+func placeDeckTop(game *GameContext, player int, position Target) error {
+	deck := game.GetPlayerState(player).Deck
+	if len(deck) == 0 || position.ID != string(models.DeckBottom) {
+		return nil
+	}
+	return game.MoveCardToDeck(player, deck[0].InstanceID, models.DeckBottom)
+}
+
 type DiscardDeckTopEffect struct {
 	BaseEffect
 }
@@ -462,8 +586,7 @@ func (e *DiscardDeckTopEffect) Apply(game *GameContext, source *models.Card, tar
 
 type DiscardNegativeEnergyEffect struct {
 	BaseEffect
-	Count    int
-	Optional bool
+	Count int
 }
 
 func (e *DiscardNegativeEnergyEffect) CanActivate(game *GameContext, source *models.Card) bool {
@@ -479,17 +602,53 @@ func (e *DiscardNegativeEnergyEffect) GetTargets(game *GameContext, source *mode
 	var targets []Target
 	playerState := game.GetPlayerState(game.ActivePlayer)
 	for i := range playerState.NegativeEnergy {
-		targets = append(targets, Target{
-			Type:     "negative_energy",
-			ID:       playerState.NegativeEnergy[i],
-			Location: "negative_energy",
-		})
+		targets = append(targets, NegativeEnergyTarget(game.ActivePlayer, i, playerState.NegativeEnergy[i]))
 	}
 	return targets
 }
 
+func (e *DiscardNegativeEnergyEffect) GetTargetSpec() TargetSpec {
+	return TargetSpec{
+		Min:         e.Count,
+		Max:         e.Count,
+		Types:       []models.TargetType{models.TargetNegativeEnergy},
+		Zones:       []models.Zone{models.ZoneNegativeEnergy},
+		Owner:       OwnerSelf,
+		Description: "破棄する負のエネルギーを選んでください",
+	}
+}
+
+func (e *DiscardNegativeEnergyEffect) NextChoice(game *GameContext, source *models.Card, answers [][]Target) *Choice {
+	if len(answers) > 0 {
+		return nil
+	}
+	
+	var options []Target
+	playerState := game.GetPlayerState(game.ActivePlayer)
+	for i, card := range playerState.NegativeEnergy {
+		options = append(options, NegativeEnergyTarget(game.ActivePlayer, i, card))
+	}
+	
+	count := e.Count
+	if count > len(options) {
+		count = len(options)
+	}
+	return &Choice{
+		Kind:        models.ChoiceKindTarget,
+		Options:     options,
+		Min:         count,
+		Max:         count,
+		Description: "破棄する負のエネルギーを選んでください",
+	}
+}
+
 func (e *DiscardNegativeEnergyEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
 	// Discard selected negative energy
+	for _, target := range targets {
+		if err := game.MoveToTrash(game.ActivePlayer, target.ID, models.ZoneNegativeEnergy); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -501,27 +660,24 @@ type ActivateByFlippingNegativeEnergyEffect struct {
 func (e *ActivateByFlippingNegativeEnergyEffect) CanActivate(game *GameContext, source *models.Card) bool {
 	playerState := game.GetPlayerState(game.ActivePlayer)
 	// Need at least Count face-up negative energy cards
-	// TODO: Count face-up negative energy
-	return len(playerState.NegativeEnergy) >= e.Count
+	return playerState.NegativeEnergy.FaceUpCount() >= e.Count
 }
 
 func (e *ActivateByFlippingNegativeEnergyEffect) GetTargets(game *GameContext, source *models.Card) []Target {
 	var targets []Target
 	playerState := game.GetPlayerState(game.ActivePlayer)
-	// TODO: Only include face-up negative energy
 	for i := range playerState.NegativeEnergy {
-		targets = append(targets, Target{
-			Type:     "negative_energy",
-			ID:       playerState.NegativeEnergy[i],
-			Location: "negative_energy",
-		})
+		if !playerState.NegativeEnergy[i].FaceUp {
+			continue
+		}
+		targets = append(targets, NegativeEnergyTarget(game.ActivePlayer, i, playerState.NegativeEnergy[i]))
 	}
 	return targets
 }
 
 func (e *ActivateByFlippingNegativeEnergyEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
-	// Flip selected negative energy and activate the attacker
-	return nil
+	// The negative energy was flipped as the ability's cost
+	return game.ActiveFriend(game.ActivePlayer, game.SourceID)
 }
 
 type HandSizePowerBoostEffect struct {
@@ -550,6 +706,14 @@ func (e *HandSizePowerBoostEffect) GetPowerBoost(game *GameContext) int {
 	return (handSize / e.CardsPerBoost) * e.PowerBoost
 }
 
+func (e *HandSizePowerBoostEffect) GetPowerModifier(game *GameContext, source *models.Card, friendPlayer int, friend models.Friend, friendCard *models.Card) int {
+	// Only boosts this friend itself
+	if friendPlayer != game.ActivePlayer || friend.InstanceID != game.SourceID {
+		return 0
+	}
+	return e.GetPowerBoost(game)
+}
+
 type MainPhasePowerBoostEffect struct {
 	BaseEffect
 	PowerBoost int
@@ -557,21 +721,31 @@ type MainPhasePowerBoostEffect struct {
 }
 
 func (e *MainPhasePowerBoostEffect) CanActivate(game *GameContext, source *models.Card) bool {
-	// Can activate during main phase if not already activated this turn
-	return game.Phase == "main" && game.ActivePlayer == game.CurrentPlayer
+	// Can activate during your own main phase
+	return game.Game.CurrentPhase == models.PhaseMain && game.ActivePlayer == game.Game.ActivePlayer
 }
 
 func (e *MainPhasePowerBoostEffect) GetTargets(game *GameContext, source *models.Card) []Target {
 	// Targets self
-	return []Target{{
-		Type:     "friend",
-		ID:       source.CardNo,
-		Location: "self",
-	}}
+	return []Target{SelfTarget(game, source)}
+}
+
+func (e *MainPhasePowerBoostEffect) GetTargetSpec() TargetSpec {
+	return TargetSpec{
+		Min:         1,
+		Max:         1,
+		Types:       []models.TargetType{models.TargetFriend},
+		Zones:       []models.Zone{models.ZoneBattleArea},
+		Owner:       OwnerSelf,
+		Description: "このふれんど",
+	}
 }
 
 func (e *MainPhasePowerBoostEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
-	// This would apply a temporary power boost
-	// Implementation would track the boost and duration
+	for _, target := range targets {
+		if err := game.ModifyPower(game.ActivePlayer, target.ID, e.PowerBoost, models.EffectDuration(e.Duration)); err != nil {
+			return err
+		}
+	}
 	return nil
 }
\ No newline at end of file
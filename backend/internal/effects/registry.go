@@ -21,7 +21,11 @@ func GetGlobalRegistry() *EffectRegistry {
 // InitializeEffects registers all card effects
 func InitializeEffects(registry *EffectRegistry) {
 	// Friend card effects
-	registry.Register("F-002", NewNamidabukuronEffect()) // なみだぶくろん - Main phase power boost
+	registry.RegisterAbility("F-002", Ability{ // なみだぶくろん - Main phase power boost
+		Trigger: TriggerMain,
+		Cost:    AbilityCost{Energy: 1},
+		Effect:  NewNamidabukuronEffect(),
+	})
 	
 	registry.Register("F-003", NewFurafuraEffect()) // フラフラ - Power boost based on hand size
 	
@@ -75,30 +79,30 @@ func InitializeEffects(registry *EffectRegistry) {
 	registry.Register("F-056", NewShiranEffect())
 	registry.Register("F-056 (P)", NewShiranEffect())
 	
-	// Support card effects
-	registry.Register("F-065", NewBardonEffect())
-	registry.Register("F-065 (P)", NewBardonEffect())
+	// Support card effects (【メイン/カウンター】 cards get both abilities)
+	registerMainCounter(registry, "F-065", NewBardonEffect())
+	registerMainCounter(registry, "F-065 (P)", NewBardonEffect())
 	
 	registry.Register("F-066", NewMasashiKurageboEffect())
 	registry.Register("F-066 (P)", NewMasashiKurageboEffect())
 	
-	registry.Register("F-067", NewDaikoubutsuEffect())
-	registry.Register("F-067 (P)", NewDaikoubutsuEffect())
+	registerMainCounter(registry, "F-067", NewDaikoubutsuEffect())
+	registerMainCounter(registry, "F-067 (P)", NewDaikoubutsuEffect())
 	
-	registry.Register("F-068", NewDecorationEffect())
-	registry.Register("F-068 (P)", NewDecorationEffect())
+	registerMainCounter(registry, "F-068", NewDecorationEffect())
+	registerMainCounter(registry, "F-068 (P)", NewDecorationEffect())
 	
-	registry.Register("F-069", NewTokuiTenEffect())
-	registry.Register("F-069 (P)", NewTokuiTenEffect())
+	registerMainCounter(registry, "F-069", NewTokuiTenEffect())
+	registerMainCounter(registry, "F-069 (P)", NewTokuiTenEffect())
 	
 	registry.Register("F-070", NewBlueDragonKickEffect())
 	registry.Register("F-070 (P)", NewBlueDragonKickEffect())
 	
-	registry.Register("F-071", NewZettaiUragiranaiFriendEffect())
+	registerMainCounter(registry, "F-071", NewZettaiUragiranaiFriendEffect())
 	
-	registry.Register("F-072", NewRyuyaYupiEffect())
+	registerMainCounter(registry, "F-072", NewRyuyaYupiEffect())
 	
-	registry.Register("F-073", NewFuruikeDivingEffect())
+	registerMainCounter(registry, "F-073", NewFuruikeDivingEffect())
 	
 	registry.Register("F-080", NewNazonoYoninEffect())
 	registry.Register("F-080 (P)", NewNazonoYoninEffect())
@@ -117,7 +121,17 @@ func InitializeEffects(registry *EffectRegistry) {
 	registry.Register("F-099", NewGomisutebaEffect())
 	
 	// Transformed cards
-	registry.Register("F-102", NewKurageboTransformEffect())
+	registry.RegisterAbility("F-102", Ability{
+		Trigger: TriggerOnAttack,
+		Cost:    AbilityCost{FlipNegativeEnergy: 2},
+		Effect:  NewKurageboTransformEffect(),
+	})
 	
 	// TODO: Add more card effects as they are discovered
-}
\ No newline at end of file
+}
+
+// registerMainCounter registers a 【メイン/カウンター】 effect as both a main and a counter ability
+func registerMainCounter(registry *EffectRegistry, cardNo string, effect Effect) {
+	registry.RegisterAbility(cardNo, Ability{Trigger: TriggerMain, Effect: effect})
+	registry.RegisterAbility(cardNo, Ability{Trigger: TriggerCounter, Effect: effect})
+}
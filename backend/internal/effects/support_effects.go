@@ -1,7 +1,7 @@
 package effects
 
 import (
-	"github.com/your-org/card_game/backend/internal/models"
+	"mememe-tcg/internal/models"
 )
 
 // Support card specific effects
@@ -10,7 +10,7 @@ import (
 func NewBardonEffect() Effect {
 	return &PowerBoostEffect{
 		BaseEffect: BaseEffect{
-			Trigger:     TriggerMain, // Can also be TriggerCounter
+			Trigger:     TriggerMain, // Also registered as a TriggerCounter ability
 			Description: "【メイン/カウンター】このターン中、自分のふれんど1体のパワー+2000。",
 		},
 		Amount:   2000,
@@ -53,7 +53,7 @@ func NewMasashiKurageboEffect() Effect {
 func NewDaikoubutsuEffect() Effect {
 	return &ReturnEnergyToHandEffect{
 		BaseEffect: BaseEffect{
-			Trigger:     TriggerMain, // Can also be TriggerCounter
+			Trigger:     TriggerMain, // Also registered as a TriggerCounter ability
 			Description: "【メイン/カウンター】自分のエネルギーエリアのカード1枚を手札に戻せる。",
 		},
 	}
@@ -63,7 +63,7 @@ func NewDaikoubutsuEffect() Effect {
 func NewDecorationEffect() Effect {
 	return &DestroyFieldCardEffect{
 		BaseEffect: BaseEffect{
-			Trigger:     TriggerMain, // Can also be TriggerCounter
+			Trigger:     TriggerMain, // Also registered as a TriggerCounter ability
 			Description: "【メイン/カウンター】相手の場のフィールドカード1枚を破壊する。",
 		},
 	}
@@ -73,7 +73,7 @@ func NewDecorationEffect() Effect {
 func NewTokuiTenEffect() Effect {
 	return &DestroyFriendEffect{
 		BaseEffect: BaseEffect{
-			Trigger:     TriggerMain, // Can also be TriggerCounter
+			Trigger:     TriggerMain, // Also registered as a TriggerCounter ability
 			Description: "【メイン/カウンター】パワー5000以下の相手のふれんど1体を破壊する。",
 		},
 		MaxPower:      5000,
@@ -97,7 +97,7 @@ func NewBlueDragonKickEffect() Effect {
 func NewZettaiUragiranaiFriendEffect() Effect {
 	return &ReviveFriendEffect{
 		BaseEffect: BaseEffect{
-			Trigger:     TriggerMain, // Can also be TriggerCounter
+			Trigger:     TriggerMain, // Also registered as a TriggerCounter ability
 			Description: "【メイン/カウンター】自分のトラッシュから、ふれんどカード1枚をコストを支払わずにレストして登場させる。この効果で登場したふれんどは、エンドフェイズに破壊される。",
 		},
 		EnterRested: true,
@@ -109,23 +109,12 @@ func NewZettaiUragiranaiFriendEffect() Effect {
 func NewRyuyaYupiEffect() Effect {
 	return &LookAndDrawEffect{
 		BaseEffect: BaseEffect{
-			Trigger:     TriggerMain, // Can also be TriggerCounter
+			Trigger:     TriggerMain, // Also registered as a TriggerCounter ability
 			Description: "【メイン/カウンター】自分はデッキの上から3枚オープンする。その中の1枚を手札に加え、残りは破棄する。自分の場に「ユピ」がいるなら、さらに自分の「ユピ」1体を手札に戻せる。",
 		},
 		LookCount: 3,
 		DrawCount: 1,
-		AdditionalEffect: func(game *GameContext, source *models.Card) error {
-			playerState := game.GetPlayerState(game.ActivePlayer)
-			// Check if player has ユピ on field
-			for pos, friend := range playerState.BattleArea {
-				if friend.CardNo == "F-023" || friend.CardNo == "F-023 (P)" {
-					// Allow returning ユピ to hand
-					_ = pos
-					return game.ReturnToHand(game.ActivePlayer, friend.CardNo)
-				}
-			}
-			return nil
-		},
+		MayReturn: []string{"F-023", "F-023 (P)"}, // ユピ
 	}
 }
 
@@ -133,7 +122,7 @@ func NewRyuyaYupiEffect() Effect {
 func NewFuruikeDivingEffect() Effect {
 	return &ReturnToHandEffect{
 		BaseEffect: BaseEffect{
-			Trigger:     TriggerMain, // Can also be TriggerCounter
+			Trigger:     TriggerMain, // Also registered as a TriggerCounter ability
 			Description: "【メイン/カウンター】ふれんど1体を手札に戻す。",
 		},
 		Scope:         ScopeTarget, // Can target any friend
@@ -167,23 +156,23 @@ func (e *PowerBoostEffect) CanActivate(game *GameContext, source *models.Card) b
 }
 
 func (e *PowerBoostEffect) GetTargets(game *GameContext, source *models.Card) []Target {
-	var targets []Target
-	playerState := game.GetPlayerState(game.ActivePlayer)
-	
-	for pos, friend := range playerState.BattleArea {
-		targets = append(targets, Target{
-			Type:     "friend",
-			ID:       friend.CardNo,
-			Location: pos,
-		})
+	return e.GetTargetSpec().Collect(game)
+}
+
+func (e *PowerBoostEffect) GetTargetSpec() TargetSpec {
+	return TargetSpec{
+		Min:         1,
+		Max:         1,
+		Types:       []models.TargetType{models.TargetFriend},
+		Zones:       []models.Zone{models.ZoneBattleArea},
+		Owner:       OwnerSelf,
+		Description: "自分のふれんど1体を選択",
 	}
-	
-	return targets
 }
 
 func (e *PowerBoostEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
 	if len(targets) > 0 {
-		return game.ModifyPower(game.ActivePlayer, targets[0].ID, e.Amount)
+		return game.ModifyPower(game.ActivePlayer, targets[0].ID, e.Amount, models.EffectDuration(e.Duration))
 	}
 	return nil
 }
@@ -213,6 +202,17 @@ func (e *CompositeEffect) GetTargets(game *GameContext, source *models.Card) []T
 	return allTargets
 }
 
+// GetTargetSpec returns the spec of the first sub-effect that picks targets,
+// since every sub-effect is applied to the same targets
+func (e *CompositeEffect) GetTargetSpec() TargetSpec {
+	for _, effect := range e.Effects {
+		if spec := effect.GetTargetSpec(); spec.Max != 0 {
+			return spec
+		}
+	}
+	return TargetSpec{}
+}
+
 func (e *CompositeEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
 	// Apply each sub-effect in order
 	for _, effect := range e.Effects {
@@ -242,19 +242,28 @@ func (e *ConditionalDestroyEffect) GetTargets(game *GameContext, source *models.
 	oppPlayer := game.GetOpponentPlayer(game.ActivePlayer)
 	oppState := game.GetPlayerState(oppPlayer)
 	
-	for pos, friend := range oppState.BattleArea {
-		if friend.Power <= e.MaxPower {
-			targets = append(targets, Target{
-				Type:     "friend",
-				ID:       friend.CardNo,
-				Location: pos,
-			})
+	for _, pos := range SortedPositions(oppState.BattleArea) {
+		friend := oppState.BattleArea[pos]
+		if game.GetFriendPower(oppPlayer, friend.InstanceID) <= e.MaxPower {
+			targets = append(targets, FriendTarget(oppPlayer, pos, friend))
 		}
 	}
 	
 	return targets
 }
 
+func (e *ConditionalDestroyEffect) GetTargetSpec() TargetSpec {
+	return TargetSpec{
+		Min:         1,
+		Max:         1,
+		Types:       []models.TargetType{models.TargetFriend},
+		Zones:       []models.Zone{models.ZoneBattleArea},
+		Owner:       OwnerOpponent,
+		MaxPower:    e.MaxPower,
+		Description: "相手のふれんど1体を選択",
+	}
+}
+
 func (e *ConditionalDestroyEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
 	if len(targets) > 0 {
 		oppPlayer := game.GetOpponentPlayer(game.ActivePlayer)
@@ -273,23 +282,24 @@ func (e *ReturnEnergyToHandEffect) CanActivate(game *GameContext, source *models
 }
 
 func (e *ReturnEnergyToHandEffect) GetTargets(game *GameContext, source *models.Card) []Target {
-	var targets []Target
-	playerState := game.GetPlayerState(game.ActivePlayer)
-	
-	for i, energy := range playerState.EnergyArea {
-		targets = append(targets, Target{
-			Type:     "energy",
-			ID:       energy.CardNo,
-			Location: string(i),
-		})
+	return e.GetTargetSpec().Collect(game)
+}
+
+func (e *ReturnEnergyToHandEffect) GetTargetSpec() TargetSpec {
+	return TargetSpec{
+		Max:         1,
+		Types:       []models.TargetType{models.TargetEnergy},
+		Zones:       []models.Zone{models.ZoneEnergyArea},
+		Owner:       OwnerSelf,
+		Description: "エネルギーエリアのカード1枚を選択",
 	}
-	
-	return targets
 }
 
 func (e *ReturnEnergyToHandEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
-	// TODO: Implement returning energy to hand
-	return nil
+	if len(targets) == 0 {
+		return nil
+	}
+	return game.MoveCard(game.ActivePlayer, targets[0].ID, models.ZoneEnergyArea, models.ZoneHand)
 }
 
 type DestroyFieldCardEffect struct {
@@ -303,25 +313,25 @@ func (e *DestroyFieldCardEffect) CanActivate(game *GameContext, source *models.C
 }
 
 func (e *DestroyFieldCardEffect) GetTargets(game *GameContext, source *models.Card) []Target {
-	oppPlayer := game.GetOpponentPlayer(game.ActivePlayer)
-	oppState := game.GetPlayerState(oppPlayer)
-	
-	if oppState.FieldCard != nil {
-		return []Target{{
-			Type:     "field",
-			ID:       *oppState.FieldCard,
-			Location: "field",
-		}}
+	return e.GetTargetSpec().Collect(game)
+}
+
+func (e *DestroyFieldCardEffect) GetTargetSpec() TargetSpec {
+	return TargetSpec{
+		Min:         1,
+		Max:         1,
+		Types:       []models.TargetType{models.TargetField},
+		Zones:       []models.Zone{models.ZoneField},
+		Owner:       OwnerOpponent,
+		Description: "相手のフィールドカードを選択",
 	}
-	
-	return nil
 }
 
 func (e *DestroyFieldCardEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
-	oppPlayer := game.GetOpponentPlayer(game.ActivePlayer)
-	// TODO: Implement destroying field card
-	_ = oppPlayer
-	return nil
+	if len(targets) == 0 {
+		return nil
+	}
+	return game.MoveToTrash(targets[0].Player, targets[0].ID, models.ZoneField)
 }
 
 type ReviveFriendEffect struct {
@@ -331,39 +341,57 @@ type ReviveFriendEffect struct {
 }
 
 func (e *ReviveFriendEffect) CanActivate(game *GameContext, source *models.Card) bool {
-	playerState := game.GetPlayerState(game.ActivePlayer)
 	// Check if there are friend cards in trash
-	// TODO: Filter for friend cards
-	return len(playerState.Trash) > 0
+	return len(e.GetTargets(game, source)) > 0
 }
 
 func (e *ReviveFriendEffect) GetTargets(game *GameContext, source *models.Card) []Target {
-	var targets []Target
-	playerState := game.GetPlayerState(game.ActivePlayer)
-	
-	// TODO: Filter for friend cards
-	for _, cardNo := range playerState.Trash {
-		targets = append(targets, Target{
-			Type:     "card",
-			ID:       cardNo,
-			Location: "trash",
-		})
+	return e.GetTargetSpec().Collect(game)
+}
+
+func (e *ReviveFriendEffect) GetTargetSpec() TargetSpec {
+	return TargetSpec{
+		Min:         1,
+		Max:         1,
+		Types:       []models.TargetType{models.TargetCard},
+		Zones:       []models.Zone{models.ZoneTrash},
+		Owner:       OwnerSelf,
+		CardTypes:   []models.CardType{models.CardTypeFriend},
+		Description: "トラッシュのふれんどカード1枚を選択",
 	}
-	
-	return targets
+}
+
+func (e *ReviveFriendEffect) SummonsFriend() bool {
+	return true
 }
 
 func (e *ReviveFriendEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
-	// TODO: Implement reviving friend from trash
-	// Need to mark for destruction at end of turn if DestroyAtEnd is true
+	if len(targets) == 0 {
+		return nil
+	}
+	
+	target := targets[0]
+	if err := game.SummonFriend(game.ActivePlayer, target.ID, models.ZoneTrash, e.EnterRested); err != nil {
+		return err
+	}
+	
+	// The revived friend is destroyed in the end phase
+	if e.DestroyAtEnd {
+		return game.RegisterDelayedTrigger(models.DelayedTrigger{
+			Player:   game.ActivePlayer,
+			Action:   models.DelayedDestroy,
+			TargetID: target.ID,
+			Timing:   models.TimingEndPhase,
+		})
+	}
 	return nil
 }
 
 type LookAndDrawEffect struct {
 	BaseEffect
-	LookCount        int
-	DrawCount        int
-	AdditionalEffect func(game *GameContext, source *models.Card) error
+	LookCount int
+	DrawCount int
+	MayReturn []string // Card numbers of a friend that may also be returned to hand
 }
 
 func (e *LookAndDrawEffect) CanActivate(game *GameContext, source *models.Card) bool {
@@ -371,16 +399,97 @@ func (e *LookAndDrawEffect) CanActivate(game *GameContext, source *models.Card)
 }
 
 func (e *LookAndDrawEffect) GetTargets(game *GameContext, source *models.Card) []Target {
-	// TODO: Show top cards of deck for selection
+	// The top cards of the deck
+	var targets []Target
+	playerState := game.GetPlayerState(game.ActivePlayer)
+	for i := 0; i < e.LookCount && i < len(playerState.Deck); i++ {
+		targets = append(targets, CardTarget(game.ActivePlayer, models.ZoneDeck, playerState.Deck[i]))
+	}
+	return targets
+}
+
+func (e *LookAndDrawEffect) GetTargetSpec() TargetSpec {
+	return TargetSpec{
+		Min:         e.DrawCount,
+		Max:         e.DrawCount,
+		Types:       []models.TargetType{models.TargetCard},
+		Zones:       []models.Zone{models.ZoneDeck},
+		Owner:       OwnerSelf,
+		Description: "手札に加えるカードを選んでください",
+	}
+}
+
+func (e *LookAndDrawEffect) NextChoice(game *GameContext, source *models.Card, answers [][]Target) *Choice {
+	switch len(answers) {
+	case 0:
+		// Pick the cards to keep among the opened ones
+		cards := e.GetTargets(game, source)
+		count := e.DrawCount
+		if count > len(cards) {
+			count = len(cards)
+		}
+		return &Choice{
+			Kind:        models.ChoiceKindTarget,
+			Options:     cards,
+			Min:         count,
+			Max:         count,
+			Description: "手札に加えるカードを選んでください",
+		}
+	case 1:
+		if len(e.MayReturn) == 0 {
+			return nil
+		}
+		
+		var friends []Target
+		playerState := game.GetPlayerState(game.ActivePlayer)
+		for _, pos := range SortedPositions(playerState.BattleArea) {
+			friend := playerState.BattleArea[pos]
+			for _, cardNo := range e.MayReturn {
+				if friend.CardNo == cardNo {
+					friends = append(friends, FriendTarget(game.ActivePlayer, pos, friend))
+				}
+			}
+		}
+		return &Choice{
+			Kind:        models.ChoiceKindTarget,
+			Options:     friends,
+			Min:         0,
+			Max:         1,
+			Description: "手札に戻すふれんどを選べます",
+		}
+	}
 	return nil
 }
 
 func (e *LookAndDrawEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
-	// TODO: Implement looking at top cards and selecting
+	opened := e.GetTargets(game, source)
 	
-	// Apply additional effect if present
-	if e.AdditionalEffect != nil {
-		return e.AdditionalEffect(game, source)
+	kept := make(map[string]bool)
+	for _, target := range targets {
+		if target.Zone == models.ZoneDeck {
+			kept[target.ID] = true
+		}
+	}
+	
+	// The chosen cards go to hand and the rest are discarded
+	for _, card := range opened {
+		var err error
+		if kept[card.ID] {
+			err = game.AddToHand(game.ActivePlayer, card.ID, models.ZoneDeck)
+		} else {
+			err = game.MoveToTrash(game.ActivePlayer, card.ID, models.ZoneDeck)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	
+	for _, target := range targets {
+		if target.Type == "friend" {
+			if err := game.ReturnToHand(game.ActivePlayer, target.ID); err != nil {
+				return err
+			}
+		}
 	}
 	
 	return nil
@@ -400,7 +509,57 @@ func (e *HandResetEffect) GetTargets(game *GameContext, source *models.Card) []T
 	return nil // No targets needed
 }
 
+// players returns the players whose hands are reset, the controller first
+func (e *HandResetEffect) players(game *GameContext) []int {
+	if !e.AffectBoth {
+		return []int{game.ActivePlayer}
+	}
+	return []int{game.ActivePlayer, game.GetOpponentPlayer(game.ActivePlayer)}
+}
+
+// NextChoice asks each player in turn which cards to discard down to the hand size
+func (e *HandResetEffect) NextChoice(game *GameContext, source *models.Card, answers [][]Target) *Choice {
+	players := e.players(game)
+	if len(answers) >= len(players) {
+		return nil
+	}
+	
+	player := players[len(answers)]
+	hand := game.GetPlayerState(player).Hand
+	excess := len(hand) - e.TargetHandSize
+	if excess < 0 {
+		excess = 0
+	}
+	var options []Target
+	if excess > 0 {
+		for _, card := range hand {
+			options = append(options, CardTarget(player, models.ZoneHand, card))
+		}
+	}
+	return &Choice{
+		Kind:        models.ChoiceKindTarget,
+		Player:      player,
+		Options:     options,
+		Min:         excess,
+		Max:         excess,
+		Description: "破棄する手札を選んでください",
+	}
+}
+
 func (e *HandResetEffect) Apply(game *GameContext, source *models.Card, targets []Target) error {
-	// TODO: Implement hand reset for both players
+	for _, target := range targets {
+		if err := game.MoveToTrash(target.Player, target.ID, models.ZoneHand); err != nil {
+			return err
+		}
+	}
+	
+	// Then each player draws back up to the hand size
+	for _, player := range e.players(game) {
+		if count := e.TargetHandSize - len(game.GetPlayerState(player).Hand); count > 0 {
+			if err := game.DrawCards(player, count); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
\ No newline at end of file
@@ -1,7 +1,10 @@
 package effects
 
 import (
-	"github.com/your-org/card_game/backend/internal/models"
+	"mememe-tcg/internal/models"
+	"slices"
+	"sort"
+	"strconv"
 )
 
 // TriggerType represents when an effect triggers
@@ -18,6 +21,7 @@ const (
 	TriggerEndPhase      TriggerType = "end_phase"      // フェイズ終了時
 	TriggerMain          TriggerType = "main"           // メイン
 	TriggerCounter       TriggerType = "counter"        // カウンター
+	TriggerOnLeavePlay   TriggerType = "on_leave_play"  // 場を離れた時
 )
 
 // EffectScope represents the scope of an effect
@@ -43,6 +47,9 @@ type Effect interface {
 	// GetTargets returns valid targets for the effect
 	GetTargets(game *GameContext, source *models.Card) []Target
 	
+	// GetTargetSpec describes which targets the effect picks and how many
+	GetTargetSpec() TargetSpec
+	
 	// Apply applies the effect to the game state
 	Apply(game *GameContext, source *models.Card, targets []Target) error
 	
@@ -50,66 +57,419 @@ type Effect interface {
 	GetDescription() string
 }
 
+// PowerModifier is implemented by persistent effects that change friends' power
+// while their source card is in play. game.ActivePlayer is the source's controller.
+type PowerModifier interface {
+	// GetPowerModifier returns the power change for a friend controlled by friendPlayer
+	GetPowerModifier(game *GameContext, source *models.Card, friendPlayer int, friend models.Friend, friendCard *models.Card) int
+}
+
+// DurationCondition is implemented by effects that apply "while" modifiers.
+// A while_condition modifier ends once its source's condition no longer holds.
+type DurationCondition interface {
+	// ConditionHolds reports whether the effect's condition is still met
+	ConditionHolds(game *GameContext, source *models.Card) bool
+}
+
+// Capability is a rule exception a persistent effect can grant to a friend
+type Capability string
+
+const (
+	CapabilityAttackImmediately Capability = "attack_immediately" // Can attack on the turn it was played
+	CapabilityBlockWhileRested  Capability = "block_while_rested" // Can block even while rested
+)
+
+// CapabilityGranter is implemented by persistent effects that let friends break
+// a rule while their source card is in play. game.ActivePlayer is the source's controller.
+type CapabilityGranter interface {
+	// GrantsCapability reports whether the friend controlled by friendPlayer has the capability
+	GrantsCapability(game *GameContext, source *models.Card, friendPlayer int, friend models.Friend, capability Capability) bool
+}
+
 // Target represents a valid target for an effect
-type Target struct {
-	Type     string      // "friend", "card", "player", etc.
-	ID       string      // Unique identifier for the target
-	Location string      // Where the target is located (battle_area, hand, etc.)
-	Data     interface{} // Additional target data
+type Target = models.Target
+
+// FriendTarget targets a friend at a position of a player's battle area
+func FriendTarget(player int, pos string, friend models.Friend) Target {
+	return Target{
+		Type:   models.TargetFriend,
+		Player: player,
+		Zone:   models.ZoneBattleArea,
+		Slot:   pos,
+		ID:     friend.InstanceID,
+		CardNo: friend.CardNo,
+	}
+}
+
+// SelfTarget targets the friend whose effect is resolving
+func SelfTarget(game *GameContext, source *models.Card) Target {
+	battleArea := game.GetPlayerState(game.ActivePlayer).BattleArea
+	for _, pos := range SortedPositions(battleArea) {
+		if friend := battleArea[pos]; friend.InstanceID == game.SourceID {
+			return FriendTarget(game.ActivePlayer, pos, friend)
+		}
+	}
+	return Target{
+		Type:   models.TargetFriend,
+		Player: game.ActivePlayer,
+		Zone:   models.ZoneBattleArea,
+		ID:     game.SourceID,
+		CardNo: source.CardNo,
+	}
+}
+
+// CardTarget targets a card in a player's deck, hand or trash
+func CardTarget(player int, zone models.Zone, card models.CardInstance) Target {
+	return Target{
+		Type:   models.TargetCard,
+		Player: player,
+		Zone:   zone,
+		ID:     card.InstanceID,
+		CardNo: card.CardNo,
+	}
+}
+
+// EnergyTarget targets the card at an index of a player's energy area
+func EnergyTarget(player int, index int, energy models.EnergyCard) Target {
+	return Target{
+		Type:   models.TargetEnergy,
+		Player: player,
+		Zone:   models.ZoneEnergyArea,
+		Slot:   strconv.Itoa(index),
+		ID:     energy.InstanceID,
+		CardNo: energy.CardNo,
+	}
+}
+
+// NegativeEnergyTarget targets the card at an index of a player's negative energy area
+func NegativeEnergyTarget(player int, index int, card models.NegativeEnergyCard) Target {
+	return Target{
+		Type:   models.TargetNegativeEnergy,
+		Player: player,
+		Zone:   models.ZoneNegativeEnergy,
+		Slot:   strconv.Itoa(index),
+		ID:     card.InstanceID,
+		CardNo: card.CardNo,
+	}
+}
+
+// FieldTarget targets a player's field card
+func FieldTarget(player int, card models.CardInstance) Target {
+	return Target{
+		Type:   models.TargetField,
+		Player: player,
+		Zone:   models.ZoneField,
+		ID:     card.InstanceID,
+		CardNo: card.CardNo,
+	}
+}
+
+// OptionTarget is a named option offered in a choice, e.g. "top" or "bottom"
+func OptionTarget(id string, label string) Target {
+	return Target{Type: models.TargetOption, ID: id, Label: label}
+}
+
+// TargetOwner is whose zones an effect's targets are in, seen from the effect's controller
+type TargetOwner string
+
+const (
+	OwnerAny      TargetOwner = ""         // どちらか
+	OwnerSelf     TargetOwner = "self"     // 自分
+	OwnerOpponent TargetOwner = "opponent" // 相手
+)
+
+// AllTargets is the TargetSpec.Max of effects that apply to every valid target
+const AllTargets = -1
+
+// TargetSpec describes the targets an effect picks. A target must pass every
+// filter that is set; empty filters and zero limits let every target through.
+type TargetSpec struct {
+	Min         int                 // Fewest targets to pick; 0 makes picking optional
+	Max         int                 // Most targets to pick; AllTargets for every valid target, 0 if the effect picks none
+	Types       []models.TargetType // Kinds of target
+	Zones       []models.Zone       // Zones the targets are in
+	Owner       TargetOwner         // Whose zones the targets are in
+	CardTypes   []models.CardType   // Card types of the targets
+	Colors      []models.CardColor  // Colors of the targets
+	MaxCost     int                 // Highest cost of a target
+	MaxPower    int                 // Highest power of a target, with modifiers for friends in play
+	Description string
+}
+
+// Mandatory reports whether the effect must pick at least one target
+func (s TargetSpec) Mandatory() bool {
+	return s.Min > 0
+}
+
+// Matches reports whether a target passes the spec's filters.
+// game.ActivePlayer is the effect's controller.
+func (s TargetSpec) Matches(game *GameContext, target Target) bool {
+	if len(s.Types) > 0 && !slices.Contains(s.Types, target.Type) {
+		return false
+	}
+	if len(s.Zones) > 0 && !slices.Contains(s.Zones, target.Zone) {
+		return false
+	}
+	switch s.Owner {
+	case OwnerSelf:
+		if target.Player != game.ActivePlayer {
+			return false
+		}
+	case OwnerOpponent:
+		if target.Player != game.GetOpponentPlayer(game.ActivePlayer) {
+			return false
+		}
+	}
+	
+	if len(s.CardTypes) == 0 && len(s.Colors) == 0 && s.MaxCost == 0 && s.MaxPower == 0 {
+		return true
+	}
+	card, err := game.GetCard(target.CardNo)
+	if err != nil {
+		return false
+	}
+	if len(s.CardTypes) > 0 && !slices.Contains(s.CardTypes, card.Type) {
+		return false
+	}
+	if len(s.Colors) > 0 && !slices.Contains(s.Colors, card.Color) {
+		return false
+	}
+	if s.MaxCost > 0 && card.Cost > s.MaxCost {
+		return false
+	}
+	if s.MaxPower > 0 {
+		power := card.Power
+		if target.Zone == models.ZoneBattleArea {
+			power = game.GetFriendPower(target.Player, target.ID)
+		}
+		if power > s.MaxPower {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the targets that pass the spec's filters, in order
+func (s TargetSpec) Filter(game *GameContext, targets []Target) []Target {
+	var filtered []Target
+	for _, target := range targets {
+		if s.Matches(game, target) {
+			filtered = append(filtered, target)
+		}
+	}
+	return filtered
+}
+
+// Collect returns every card in the spec's zones that passes its filters. The
+// controller's cards come first, and battle areas are in position order.
+func (s TargetSpec) Collect(game *GameContext) []Target {
+	players := []int{game.ActivePlayer, game.GetOpponentPlayer(game.ActivePlayer)}
+	switch s.Owner {
+	case OwnerSelf:
+		players = players[:1]
+	case OwnerOpponent:
+		players = players[1:]
+	}
+	
+	var targets []Target
+	for _, player := range players {
+		playerState := game.GetPlayerState(player)
+		for _, zone := range s.Zones {
+			switch zone {
+			case models.ZoneBattleArea:
+				for _, pos := range SortedPositions(playerState.BattleArea) {
+					targets = append(targets, FriendTarget(player, pos, playerState.BattleArea[pos]))
+				}
+			case models.ZoneDeck:
+				for _, card := range playerState.Deck {
+					targets = append(targets, CardTarget(player, zone, card))
+				}
+			case models.ZoneHand:
+				for _, card := range playerState.Hand {
+					targets = append(targets, CardTarget(player, zone, card))
+				}
+			case models.ZoneTrash:
+				for _, card := range playerState.Trash {
+					targets = append(targets, CardTarget(player, zone, card))
+				}
+			case models.ZoneEnergyArea:
+				for i, energy := range playerState.EnergyArea {
+					targets = append(targets, EnergyTarget(player, i, energy))
+				}
+			case models.ZoneNegativeEnergy:
+				for i, card := range playerState.NegativeEnergy {
+					targets = append(targets, NegativeEnergyTarget(player, i, card))
+				}
+			case models.ZoneField:
+				if playerState.FieldCard != nil {
+					targets = append(targets, FieldTarget(player, *playerState.FieldCard))
+				}
+			}
+		}
+	}
+	return s.Filter(game, targets)
+}
+
+// OptionalAbility is implemented by effects whose controller may decline them.
+// Every effect built on BaseEffect implements it through its Optional flag.
+type OptionalAbility interface {
+	IsOptional() bool
+	AcceptsByDefault() bool // Answer used when the controller does not respond in time
+}
+
+// FriendSummoner is implemented by effects that put a friend into their
+// controller's battle area. When it is full, the controller first picks a
+// friend there to put in the trash.
+type FriendSummoner interface {
+	SummonsFriend() bool
+}
+
+// Choice is a decision the controller of an effect makes while it resolves
+type Choice struct {
+	Kind        models.ChoiceKind
+	Player      int // Player who makes the choice, the effect's controller if 0
+	Options     []Target
+	Min         int // 0 makes the choice optional ("できる")
+	Max         int
+	Description string
+}
+
+// ChoiceEffect is implemented by effects that need decisions from the players.
+// NextChoice gets the options picked for each choice made so far and returns the
+// next choice, or nil once the effect can apply. Apply then receives every picked
+// option as its targets.
+type ChoiceEffect interface {
+	NextChoice(game *GameContext, source *models.Card, answers [][]Target) *Choice
+}
+
+// Picked returns the targets picked for one choice, or nil if it was not made
+func Picked(answers [][]Target, choice int) []Target {
+	if choice >= len(answers) {
+		return nil
+	}
+	return answers[choice]
+}
+
+// SortedPositions returns the battle area positions in a stable order, so
+// effects list and pick targets the same way every time
+func SortedPositions(battleArea map[string]models.Friend) []string {
+	positions := make([]string, 0, len(battleArea))
+	for pos := range battleArea {
+		positions = append(positions, pos)
+	}
+	sort.Strings(positions)
+	return positions
 }
 
 // GameContext provides access to the game state and methods to modify it
 type GameContext struct {
 	Game         *models.Game
-	ActivePlayer int // 1 or 2
+	ActivePlayer int    // 1 or 2
+	SourceID     string // Instance ID of the card whose effect is being evaluated
 	
-	// Helper methods to modify game state
+	// Helper methods to modify game state. Cards are identified by instance ID.
 	DrawCards         func(player int, count int) error
-	DestroyFriend     func(player int, cardNo string) error
-	ReturnToHand      func(player int, cardNo string) error
-	RestFriend        func(player int, cardNo string) error
-	ActiveFriend      func(player int, cardNo string) error
-	ModifyPower       func(player int, cardNo string, amount int) error
+	DestroyFriend     func(player int, instanceID string) error
+	ReturnToHand      func(player int, instanceID string) error
+	RestFriend        func(player int, instanceID string) error
+	ActiveFriend      func(player int, instanceID string) error
+	ActiveEnergy      func(player int, instanceID string) error
+	ModifyPower       func(player int, instanceID string, amount int, duration models.EffectDuration) error
 	RevealNegEnergy   func(player int, count int) error
-	PlaceFieldCard    func(player int, cardNo string) error
-	MoveToTrash       func(player int, cardNo string, from string) error
-	MoveCardToDeck    func(player int, cardNo string, position string) error // "top" or "bottom"
+	PlaceFieldCard    func(player int, instanceID string) error
+	MoveToTrash       func(player int, instanceID string, from models.Zone) error
+	DiscardFromDeckTop func(player int, count int) error
+	MoveCardToDeck    func(player int, instanceID string, position models.DeckPosition) error
+	AddToHand         func(player int, instanceID string, from models.Zone) error // From the deck or trash
 	DealDamage        func(player int, amount int) error
-	AddToEnergyArea   func(player int, cardNo string) error
+	AddToEnergyArea   func(player int, instanceID string) error
+	SummonFriend      func(player int, instanceID string, from models.Zone, rested bool) error // Put a friend into play without paying its cost
+	MoveCard          func(player int, instanceID string, from, to models.Zone) error       // Move a card between any two zones
+	RegisterDelayedTrigger func(trigger models.DelayedTrigger) error
 	GetPlayerState    func(player int) *models.PlayerState
+	GetFriendPower    func(player int, instanceID string) int // Effective power with all modifiers
+	GetCard           func(cardNo string) (*models.Card, error)
 	GetOpponentPlayer func(player int) int
 }
 
-// EffectRegistry holds all registered effects
+// AbilityCost is what a player pays to use an ability
+type AbilityCost struct {
+	Energy             int  `json:"energy,omitempty"`               // Energy cards to rest
+	Rest               bool `json:"rest,omitempty"`                 // Rest the card that has the ability
+	FlipNegativeEnergy int  `json:"flip_negative_energy,omitempty"` // Face-up negative energy cards to turn face down
+}
+
+// IsFree reports whether the ability can be used without paying anything
+func (c AbilityCost) IsFree() bool {
+	return c.Energy == 0 && !c.Rest && c.FlipNegativeEnergy == 0
+}
+
+// Ability is one ability of a card. A card can have several abilities, each
+// with its own trigger (timing) and cost, and several abilities can share an
+// effect, e.g. 【メイン/カウンター】 support cards.
+type Ability struct {
+	CardNo      string
+	Index       int // Position among the card's abilities, in registration order
+	Trigger     TriggerType
+	Cost        AbilityCost
+	OncePerTurn bool // 【1ターンに1回】: each copy of the card can use it once per turn
+	Effect      Effect
+}
+
+// EffectRegistry holds all registered abilities
 type EffectRegistry struct {
-	effects map[string]Effect
+	abilities map[string][]Ability
 }
 
 // NewEffectRegistry creates a new effect registry
 func NewEffectRegistry() *EffectRegistry {
 	return &EffectRegistry{
-		effects: make(map[string]Effect),
+		abilities: make(map[string][]Ability),
 	}
 }
 
-// Register registers an effect for a card
+// Register registers an effect for a card as an ability using the effect's own trigger
 func (r *EffectRegistry) Register(cardNo string, effect Effect) {
-	r.effects[cardNo] = effect
+	r.RegisterAbility(cardNo, Ability{Trigger: effect.GetTrigger(), Effect: effect})
 }
 
-// GetEffect returns the effect for a card
+// RegisterAbility adds another ability to a card
+func (r *EffectRegistry) RegisterAbility(cardNo string, ability Ability) {
+	ability.CardNo = cardNo
+	ability.Index = len(r.abilities[cardNo])
+	r.abilities[cardNo] = append(r.abilities[cardNo], ability)
+}
+
+// GetAbilities returns all abilities of a card
+func (r *EffectRegistry) GetAbilities(cardNo string) []Ability {
+	return r.abilities[cardNo]
+}
+
+// GetEffect returns the effect of a card's first ability
 func (r *EffectRegistry) GetEffect(cardNo string) (Effect, bool) {
-	effect, exists := r.effects[cardNo]
-	return effect, exists
+	abilities := r.abilities[cardNo]
+	if len(abilities) == 0 {
+		return nil, false
+	}
+	return abilities[0].Effect, true
 }
 
-// GetEffectsForTrigger returns all effects that trigger on a specific event
-func (r *EffectRegistry) GetEffectsForTrigger(trigger TriggerType) []string {
-	var cardNos []string
-	for cardNo, effect := range r.effects {
-		if effect.GetTrigger() == trigger {
-			cardNos = append(cardNos, cardNo)
+// GetEffectsForTrigger returns every ability that triggers on a specific event,
+// ordered by card number and ability index
+func (r *EffectRegistry) GetEffectsForTrigger(trigger TriggerType) []Ability {
+	cardNos := make([]string, 0, len(r.abilities))
+	for cardNo := range r.abilities {
+		cardNos = append(cardNos, cardNo)
+	}
+	sort.Strings(cardNos)
+	
+	var matches []Ability
+	for _, cardNo := range cardNos {
+		for _, ability := range r.abilities[cardNo] {
+			if ability.Trigger == trigger {
+				matches = append(matches, ability)
+			}
 		}
 	}
-	return cardNos
-}
\ No newline at end of file
+	return matches
+}
@@ -0,0 +1,200 @@
+package game
+
+import (
+	"fmt"
+	
+	"mememe-tcg/internal/effects"
+	"mememe-tcg/internal/models"
+)
+
+// ActivateAbility uses a 【メイン】 ability of a friend or field card the player
+// controls. The cost is paid first, with the selected energy cards for its energy
+// part, and the ability then resolves through the effect stack.
+func (h *EventHandler) ActivateAbility(player int, instanceID string, abilityIndex int, energy []int) error {
+	if err := h.ValidateActivateAbility(player, instanceID, abilityIndex); err != nil {
+		return err
+	}
+	
+	src, _ := h.findSource(player, instanceID)
+	card, err := h.loadCard(src.cardNo)
+	if err != nil {
+		return err
+	}
+	ability := h.effectRegistry.GetAbilities(src.cardNo)[abilityIndex]
+	
+	if err := h.payAbilityCost(player, instanceID, ability.Cost, energy); err != nil {
+		return err
+	}
+	if ability.OncePerTurn {
+		h.recordAbilityUse(instanceID, abilityIndex)
+	}
+	
+	h.resolver.Push([]EffectStackItem{{
+		Effect:   ability.Effect,
+		Source:   card,
+		Ability:  abilityIndex,
+		SourceID: instanceID,
+		Player:   player,
+		CostPaid: true,
+	}})
+	if err := h.resolver.ResolveAll(); err != nil {
+		return err
+	}
+	
+	h.processPersistentEffects()
+	h.context.SourceID = ""
+	
+	return nil
+}
+
+// ValidateActivateAbility checks that a player may use an ability of their card in play now
+func (h *EventHandler) ValidateActivateAbility(player int, instanceID string, abilityIndex int) error {
+	if err := h.validateMainPhaseAction(player); err != nil {
+		return err
+	}
+	
+	src, ok := h.findSource(player, instanceID)
+	if !ok {
+		return violation(models.RuleNotInPlay, "%s is not in play", instanceID)
+	}
+	
+	abilities := h.effectRegistry.GetAbilities(src.cardNo)
+	if abilityIndex < 0 || abilityIndex >= len(abilities) || abilities[abilityIndex].Trigger != effects.TriggerMain {
+		return violation(models.RuleNoAbility, "%s has no 【メイン】 ability %d", src.cardNo, abilityIndex)
+	}
+	ability := abilities[abilityIndex]
+	
+	if ability.OncePerTurn && h.abilityUsed(instanceID, abilityIndex) {
+		return violation(models.RuleAbilityUsed, "%s already used this ability this turn", instanceID)
+	}
+	if err := h.canPayAbilityCost(player, instanceID, ability.Cost); err != nil {
+		return err
+	}
+	
+	card, err := h.loadCard(src.cardNo)
+	if err != nil {
+		return err
+	}
+	h.context.ActivePlayer = player
+	h.context.SourceID = instanceID
+	if !ability.Effect.CanActivate(h.context, card) {
+		return violation(models.RuleNoAbility, "%s cannot use this ability now", instanceID)
+	}
+	
+	return nil
+}
+
+// findSource returns the player's friend or field card with the given instance ID
+func (h *EventHandler) findSource(player int, instanceID string) (cardSource, bool) {
+	for _, src := range h.getCardSources() {
+		if src.player == player && src.instanceID == instanceID {
+			return src, true
+		}
+	}
+	return cardSource{}, false
+}
+
+// canPayAbilityCost checks that a player can pay an ability's cost right now
+func (h *EventHandler) canPayAbilityCost(player int, instanceID string, cost effects.AbilityCost) error {
+	playerState := h.context.GetPlayerState(player)
+	
+	if cost.Rest {
+		pos, ok := findFriend(playerState, instanceID)
+		if !ok {
+			return violation(models.RuleCannotPay, "only a friend can rest to pay for its ability")
+		}
+		if playerState.BattleArea[pos].IsRest {
+			return violation(models.RuleFriendRested, "friend at position %s is rested", pos)
+		}
+	}
+	if cost.FlipNegativeEnergy > playerState.NegativeEnergy.FaceUpCount() {
+		return violation(models.RuleCannotPay, "%d face-up negative energy cards are needed", cost.FlipNegativeEnergy)
+	}
+	if _, _, ok := h.selectEnergy(player, cost.Energy, nil, false); !ok {
+		return violation(models.RuleCannotPay, "%d energy is needed", cost.Energy)
+	}
+	
+	return nil
+}
+
+// payAbilityCost pays an ability's cost. A nil energy selection picks the
+// energy cards automatically.
+func (h *EventHandler) payAbilityCost(player int, instanceID string, cost effects.AbilityCost, energy []int) error {
+	if err := h.canPayAbilityCost(player, instanceID, cost); err != nil {
+		return err
+	}
+	
+	if energy == nil {
+		energy, _, _ = h.selectEnergy(player, cost.Energy, nil, false)
+	}
+	if err := h.payEnergy(player, instanceID, cost.Energy, nil, energy, nil); err != nil {
+		return err
+	}
+	
+	playerState := h.context.GetPlayerState(player)
+	if cost.Rest {
+		pos, _ := findFriend(playerState, instanceID)
+		friend := playerState.BattleArea[pos]
+		friend.IsRest = true
+		playerState.BattleArea[pos] = friend
+	}
+	
+	// The oldest face-up negative energy cards are turned face down
+	var flipped []int
+	for i, card := range playerState.NegativeEnergy {
+		if card.FaceUp && len(flipped) < cost.FlipNegativeEnergy {
+			flipped = append(flipped, i)
+		}
+	}
+	return h.flipNegEnergyDown(player, flipped)
+}
+
+// flipNegEnergyDown turns the selected face-up negative energy cards face down to pay a cost
+func (h *EventHandler) flipNegEnergyDown(player int, indices []int) error {
+	if len(indices) == 0 {
+		return nil
+	}
+	
+	playerState := h.context.GetPlayerState(player)
+	for _, index := range indices {
+		if index < 0 || index >= len(playerState.NegativeEnergy) || !playerState.NegativeEnergy[index].FaceUp {
+			return fmt.Errorf("no face-up negative energy at index %d", index)
+		}
+	}
+	for _, index := range indices {
+		playerState.NegativeEnergy[index].FaceUp = false
+	}
+	
+	return h.emit(GameEvent{
+		Type:   EventNegEnergyFlipped,
+		Player: player,
+		Phase:  h.game.CurrentPhase,
+		Data:   map[string]interface{}{"count": len(indices)},
+	})
+}
+
+// abilityUsed reports whether a card used a once-per-turn ability this turn
+func (h *EventHandler) abilityUsed(instanceID string, abilityIndex int) bool {
+	for _, use := range h.game.GameState.AbilityUses {
+		if use.SourceID == instanceID && use.Ability == abilityIndex && use.Turn == h.game.CurrentTurn {
+			return true
+		}
+	}
+	return false
+}
+
+// recordAbilityUse remembers a once-per-turn ability use, forgetting uses from earlier turns
+func (h *EventHandler) recordAbilityUse(instanceID string, abilityIndex int) {
+	uses := []models.AbilityUse{}
+	for _, use := range h.game.GameState.AbilityUses {
+		if use.Turn == h.game.CurrentTurn {
+			uses = append(uses, use)
+		}
+	}
+	
+	h.game.GameState.AbilityUses = append(uses, models.AbilityUse{
+		SourceID: instanceID,
+		Ability:  abilityIndex,
+		Turn:     h.game.CurrentTurn,
+	})
+}
@@ -0,0 +1,125 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+	
+	"mememe-tcg/internal/effects"
+	"mememe-tcg/internal/models"
+)
+
+// newAbilityHandler returns a handler where "V-MAIN" has three abilities that
+// record their use in log: 【メイン：1 energy and 1 negative energy, once per turn】,
+// 【メイン：rest this friend】 and an on-play ability that cannot be activated
+func newAbilityHandler(g *models.Game, log *[]string) *EventHandler {
+	registry := effects.NewEffectRegistry()
+	registry.RegisterAbility("V-MAIN", effects.Ability{
+		Trigger:     effects.TriggerMain,
+		Cost:        effects.AbilityCost{Energy: 1, FlipNegativeEnergy: 1},
+		OncePerTurn: true,
+		Effect:      &logEffect{BaseEffect: effects.BaseEffect{Trigger: effects.TriggerMain}, log: log},
+	})
+	registry.RegisterAbility("V-MAIN", effects.Ability{
+		Trigger: effects.TriggerMain,
+		Cost:    effects.AbilityCost{Rest: true},
+		Effect:  &logEffect{BaseEffect: effects.BaseEffect{Trigger: effects.TriggerMain}, log: log},
+	})
+	registry.Register("V-MAIN", &logEffect{BaseEffect: effects.BaseEffect{Trigger: effects.TriggerOnPlay}, log: log})
+	
+	h := NewEventHandler(g, loadTestCard)
+	h.effectRegistry = registry
+	return h
+}
+
+func TestActivateAbility(t *testing.T) {
+	g := newTestGame()
+	playerState := &g.GameState.Player1State
+	playerState.BattleArea["0"] = models.Friend{InstanceID: "main", CardNo: "V-MAIN", Power: 1000, TurnPlayed: 3}
+	playerState.EnergyArea = []models.EnergyCard{{InstanceID: "e1", Color: models.ColorRed}, {InstanceID: "e2", Color: models.ColorBlue}}
+	playerState.NegativeEnergy = models.NegativeEnergyZone{{InstanceID: "n1", CardNo: "V-1000", FaceUp: true}, {InstanceID: "n2", CardNo: "V-1000", FaceUp: true}}
+	var log []string
+	h := newAbilityHandler(g, &log)
+	
+	// The cost is paid with the picked energy and the oldest face-up negative energy
+	if err := h.ActivateAbility(1, "main", 0, []int{1}); err != nil {
+		t.Fatalf("ActivateAbility: %v", err)
+	}
+	if !reflect.DeepEqual(log, []string{"main"}) {
+		t.Errorf("resolved %v, want the ability once", log)
+	}
+	if playerState.EnergyArea[0].IsRest || !playerState.EnergyArea[1].IsRest {
+		t.Errorf("energy rest = %v, %v; want only the picked one", playerState.EnergyArea[0].IsRest, playerState.EnergyArea[1].IsRest)
+	}
+	if playerState.NegativeEnergy[0].FaceUp || !playerState.NegativeEnergy[1].FaceUp {
+		t.Errorf("negative energy face up = %v, %v; want only the newest", playerState.NegativeEnergy[0].FaceUp, playerState.NegativeEnergy[1].FaceUp)
+	}
+	
+	// Once per turn, even with the cost available again
+	if err := h.ActivateAbility(1, "main", 0, nil); ruleOf(err) != models.RuleAbilityUsed {
+		t.Errorf("second use = %v, want rule %q", err, models.RuleAbilityUsed)
+	}
+	
+	// The rest cost can be paid once while the friend stands
+	if err := h.ActivateAbility(1, "main", 1, nil); err != nil {
+		t.Fatalf("ActivateAbility: %v", err)
+	}
+	if !playerState.BattleArea["0"].IsRest {
+		t.Errorf("the friend did not rest to pay for its ability")
+	}
+	if err := h.ActivateAbility(1, "main", 1, nil); ruleOf(err) != models.RuleFriendRested {
+		t.Errorf("use while rested = %v, want rule %q", err, models.RuleFriendRested)
+	}
+	
+	// A later turn allows the once-per-turn ability again
+	g.CurrentTurn += 2
+	if err := h.ActivateAbility(1, "main", 0, nil); err != nil {
+		t.Fatalf("ActivateAbility on a later turn: %v", err)
+	}
+	if want := []string{"main", "main", "main"}; !reflect.DeepEqual(log, want) {
+		t.Errorf("resolved %v, want %v", log, want)
+	}
+	if len(g.GameState.AbilityUses) != 1 {
+		t.Errorf("ability uses = %+v, want only this turn's", g.GameState.AbilityUses)
+	}
+}
+
+func TestActivateAbilityViolations(t *testing.T) {
+	tests := []struct {
+		name       string
+		player     int
+		instanceID string
+		ability    int
+		energy     bool
+		rule       models.Rule
+	}{
+		{name: "cost cannot be paid", player: 1, instanceID: "main", ability: 0, rule: models.RuleCannotPay},
+		{name: "not a 【メイン】 ability", player: 1, instanceID: "main", ability: 2, energy: true, rule: models.RuleNoAbility},
+		{name: "no such ability", player: 1, instanceID: "main", ability: 3, energy: true, rule: models.RuleNoAbility},
+		{name: "not in play", player: 1, instanceID: "p1-d1", ability: 0, energy: true, rule: models.RuleNotInPlay},
+		{name: "opponent's card", player: 1, instanceID: "their-main", ability: 0, energy: true, rule: models.RuleNotInPlay},
+		{name: "opponent's turn", player: 2, instanceID: "their-main", ability: 0, energy: true, rule: models.RuleNotYourTurn},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+			g.GameState.Player1State.BattleArea["0"] = models.Friend{InstanceID: "main", CardNo: "V-MAIN", Power: 1000, TurnPlayed: 3}
+			g.GameState.Player2State.BattleArea["0"] = models.Friend{InstanceID: "their-main", CardNo: "V-MAIN", Power: 1000, TurnPlayed: 2}
+			for _, playerState := range []*models.PlayerState{&g.GameState.Player1State, &g.GameState.Player2State} {
+				playerState.NegativeEnergy = models.NegativeEnergyZone{{InstanceID: "n1", CardNo: "V-1000", FaceUp: true}}
+				if tt.energy {
+					playerState.EnergyArea = []models.EnergyCard{{InstanceID: "e1", Color: models.ColorRed}}
+				}
+			}
+			var log []string
+			h := newAbilityHandler(g, &log)
+			
+			if err := h.ActivateAbility(tt.player, tt.instanceID, tt.ability, nil); ruleOf(err) != tt.rule {
+				t.Fatalf("ActivateAbility = %v, want rule %q", err, tt.rule)
+			}
+			if len(log) != 0 || g.GameState.Player1State.NegativeEnergy.FaceUpCount() != 1 {
+				t.Errorf("a rejected activation resolved %v or paid its cost", log)
+			}
+		})
+	}
+}
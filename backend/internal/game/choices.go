@@ -0,0 +1,89 @@
+package game
+
+import (
+	"time"
+	
+	"mememe-tcg/internal/models"
+)
+
+// EffectChoiceTimeout is how long a player has to answer an effect choice
+// before its default selection is used
+const EffectChoiceTimeout = 60 * time.Second
+
+// EffectChoicePending reports whether effect resolution waits for a player's choice
+func (h *EventHandler) EffectChoicePending() bool {
+	return h.game.GameState != nil && h.game.GameState.PendingEffect != nil
+}
+
+// ResolveEffectChoice answers the choice a paused effect is waiting for and
+// resolves the rest of the effect stack. If the stack paused during the
+// automatic phase steps, the game carries on from where it stopped.
+func (h *EventHandler) ResolveEffectChoice(player int, choiceID string, selection []int) error {
+	if h.game.GameState.PendingEffect == nil {
+		return violation(models.RuleChoicePending, "no effect choice is pending")
+	}
+	resume := h.game.GameState.PendingEffect.Resume
+	
+	if err := h.interaction.SubmitChoice(player, choiceID, selection); err != nil {
+		return err
+	}
+	
+	h.processPersistentEffects()
+	h.context.SourceID = ""
+	
+	// Another choice came up before the stack was empty
+	if h.EffectChoicePending() || CheckVictory(h.game) {
+		return nil
+	}
+	
+	return h.continueFrom(resume)
+}
+
+// ExpireEffectChoice answers the pending effect choice with its default selection
+// once its deadline has passed. Returns true when a choice expired.
+func (h *EventHandler) ExpireEffectChoice(now time.Time) (bool, error) {
+	pending := h.game.GameState.PendingEffect
+	if h.game.Status != models.StatusPlaying || pending == nil || now.Before(pending.Choice.Deadline) {
+		return false, nil
+	}
+	
+	choice := pending.Choice
+	return true, h.ResolveEffectChoice(choice.Player, choice.ID, choice.Default)
+}
+
+// pausedAt reports whether effect resolution is waiting for a choice. The
+// first automatic step that stops because of it is where the game resumes.
+func (h *EventHandler) pausedAt(step models.ResumeStep) bool {
+	pending := h.game.GameState.PendingEffect
+	if pending == nil {
+		return false
+	}
+	if pending.Resume == "" {
+		pending.Resume = step
+	}
+	return true
+}
+
+// continueFrom runs the automatic game steps that were interrupted by a choice
+func (h *EventHandler) continueFrom(step models.ResumeStep) error {
+	switch step {
+	case models.ResumePhaseStep:
+		if done, err := h.finishPhase(); err != nil || done {
+			return err
+		}
+	case models.ResumePhaseEnd:
+		if err := h.nextPhase(); err != nil {
+			return err
+		}
+	case models.ResumeTurnEnd:
+		if err := h.passTurn(); err != nil {
+			return err
+		}
+	case models.ResumeTurnStart:
+		CheckVictory(h.game)
+	default:
+		return nil
+	}
+	
+	return h.runPhases()
+}
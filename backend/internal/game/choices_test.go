@@ -0,0 +1,100 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+	
+	"mememe-tcg/internal/models"
+)
+
+// F-042 pauses once for each trash card to return and once for its deck position.
+// Every answer is given to a fresh handler on a reloaded game, as the service does.
+func TestEffectChoicePauseAndResume(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers [][]int
+		deck    []string
+		trash   []string
+	}{
+		{name: "decline", answers: [][]int{{}}, deck: []string{"p1-d1"}, trash: []string{"t1", "t2", "t3"}},
+		{name: "one card to the bottom", answers: [][]int{{1}, {1}, {}}, deck: []string{"p1-d1", "t2"}, trash: []string{"t1", "t3"}},
+		{name: "top and bottom", answers: [][]int{{0}, {0}, {1}, {1}, {}}, deck: []string{"t1", "p1-d1", "t3"}, trash: []string{"t2"}},
+		{name: "every card", answers: [][]int{{2}, {0}, {1}, {0}, {0}, {1}}, deck: []string{"t2", "t3", "p1-d1", "t1"}, trash: []string{}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+			g.GameState.Player1State.Deck = g.GameState.Player1State.Deck[:1]
+			g.GameState.Player1State.Trash = []models.CardInstance{{InstanceID: "t1", CardNo: "V-1000"}, {InstanceID: "t2", CardNo: "V-1000"}, {InstanceID: "t3", CardNo: "V-3000"}}
+			g.GameState.Player1State.BattleArea["0"] = models.Friend{InstanceID: "ukki", CardNo: "F-042", Power: 2000, TurnPlayed: 3}
+			h := NewEventHandler(g, loadTestCard)
+	
+			if err := h.TriggerEvent(GameEvent{Type: EventFriendPlayed, Player: 1, CardNo: "F-042", InstanceID: "ukki", Phase: g.CurrentPhase}); err != nil {
+				t.Fatalf("TriggerEvent: %v", err)
+			}
+	
+			for i, selection := range tt.answers {
+				pending := g.GameState.PendingEffect
+				if pending == nil {
+					t.Fatalf("answer %d: no choice is pending", i)
+				}
+				if err := h.ValidateEndPhase(1); ruleOf(err) != models.RuleChoicePending {
+					t.Errorf("answer %d: ending the phase = %v, want a pending choice", i, err)
+				}
+				if err := h.ResolveEffectChoice(2, pending.Choice.ID, selection); ruleOf(err) != models.RuleNotYourTurn {
+					t.Errorf("answer %d: opponent answered: %v", i, err)
+				}
+	
+				g = reload(t, g)
+				h = NewEventHandler(g, loadTestCard)
+				if err := h.ResolveEffectChoice(1, pending.Choice.ID, selection); err != nil {
+					t.Fatalf("answer %d: %v", i, err)
+				}
+			}
+	
+			if g.GameState.PendingEffect != nil {
+				t.Fatalf("choice still pending: %+v", g.GameState.PendingEffect.Choice)
+			}
+			playerState := g.GameState.Player1State
+			if got := instanceIDs(playerState.Deck); !reflect.DeepEqual(got, tt.deck) {
+				t.Errorf("deck = %v, want %v", got, tt.deck)
+			}
+			if got := instanceIDs(playerState.Trash); !reflect.DeepEqual(got, tt.trash) {
+				t.Errorf("trash = %v, want %v", got, tt.trash)
+			}
+		})
+	}
+}
+
+func TestEffectChoiceRejectsInvalidSelections(t *testing.T) {
+	tests := []struct {
+		name      string
+		selection []int
+		rule      models.Rule
+	}{
+		{name: "too many", selection: []int{0, 1}, rule: models.RuleInvalidTarget},
+		{name: "out of range", selection: []int{3}, rule: models.RuleInvalidTarget},
+		{name: "negative", selection: []int{-1}, rule: models.RuleInvalidTarget},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+			g.GameState.Player1State.Trash = []models.CardInstance{{InstanceID: "t1", CardNo: "V-1000"}, {InstanceID: "t2", CardNo: "V-1000"}, {InstanceID: "t3", CardNo: "V-1000"}}
+			g.GameState.Player1State.BattleArea["0"] = models.Friend{InstanceID: "ukki", CardNo: "F-042", Power: 2000, TurnPlayed: 3}
+			h := NewEventHandler(g, loadTestCard)
+			if err := h.TriggerEvent(GameEvent{Type: EventFriendPlayed, Player: 1, CardNo: "F-042", InstanceID: "ukki", Phase: g.CurrentPhase}); err != nil {
+				t.Fatalf("TriggerEvent: %v", err)
+			}
+	
+			choiceID := g.GameState.PendingEffect.Choice.ID
+			if err := h.ResolveEffectChoice(1, choiceID, tt.selection); ruleOf(err) != tt.rule {
+				t.Fatalf("ResolveEffectChoice = %v, want rule %q", err, tt.rule)
+			}
+			if g.GameState.PendingEffect == nil || g.GameState.PendingEffect.Choice.ID != choiceID {
+				t.Errorf("a rejected answer did not keep the choice pending")
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package game
+
+import (
+	"mememe-tcg/internal/effects"
+	"mememe-tcg/internal/models"
+)
+
+// DeclareAttack rests the attacker, fires its attack triggers and opens the block window.
+// An empty targetPos (or "player") attacks the opponent directly.
+func (h *EventHandler) DeclareAttack(player int, attackerPos string, targetPos string) error {
+	if targetPos == "player" {
+		targetPos = ""
+	}
+	if err := h.ValidateAttack(player, attackerPos, targetPos); err != nil {
+		return err
+	}
+	
+	state := h.game.GameState
+	playerState := h.context.GetPlayerState(player)
+	attacker := playerState.BattleArea[attackerPos]
+	
+	// Attacking rests the friend
+	attacker.IsRest = true
+	playerState.BattleArea[attackerPos] = attacker
+	
+	state.PendingAttack = &models.PendingAttack{
+		AttackerPlayer: player,
+		AttackerPos:    attackerPos,
+		TargetPos:      targetPos,
+		Step:           models.AttackStepBlock,
+	}
+	
+	// Trigger attack event
+	return h.TriggerEvent(GameEvent{
+		Type:       EventFriendAttacks,
+		Player:     player,
+		CardNo:     attacker.CardNo,
+		InstanceID: attacker.InstanceID,
+		Target:     targetPos,
+		Phase:      h.game.CurrentPhase,
+	})
+}
+
+// DeclareBlock rests the blocker, fires its block triggers and opens the counter window
+func (h *EventHandler) DeclareBlock(player int, blockerPos string) error {
+	if err := h.ValidateBlock(player, blockerPos); err != nil {
+		return err
+	}
+	
+	attack := h.game.GameState.PendingAttack
+	playerState := h.context.GetPlayerState(player)
+	blocker := playerState.BattleArea[blockerPos]
+	
+	// Blocking rests the friend
+	blocker.IsRest = true
+	playerState.BattleArea[blockerPos] = blocker
+	
+	attack.BlockerPos = blockerPos
+	attack.Step = models.AttackStepCounter
+	
+	// Trigger block event
+	return h.TriggerEvent(GameEvent{
+		Type:       EventFriendBlocks,
+		Player:     player,
+		CardNo:     blocker.CardNo,
+		InstanceID: blocker.InstanceID,
+		Target:     attack.AttackerPos,
+		Phase:      h.game.CurrentPhase,
+	})
+}
+
+// PassAttackStep lets the defender decline the current window.
+// Passing the block window opens the counter window; passing the counter window resolves the battle.
+func (h *EventHandler) PassAttackStep(player int) error {
+	attack := h.game.GameState.PendingAttack
+	if attack == nil {
+		return violation(models.RuleNoAttack, "no attack in progress")
+	}
+	
+	if _, err := h.getDefenderWindow(player, attack.Step); err != nil {
+		return err
+	}
+	
+	switch attack.Step {
+	case models.AttackStepBlock:
+		attack.Step = models.AttackStepCounter
+		return nil
+	default:
+		return h.resolveAttack()
+	}
+}
+
+// getDefenderWindow returns the pending attack if the player is the defender and it waits in the given step
+func (h *EventHandler) getDefenderWindow(player int, step models.AttackStep) (*models.PendingAttack, error) {
+	attack := h.game.GameState.PendingAttack
+	if attack == nil {
+		return nil, violation(models.RuleNoAttack, "no attack in progress")
+	}
+	if player == attack.AttackerPlayer {
+		return nil, violation(models.RuleNotDefender, "only the defending player can respond to an attack")
+	}
+	if attack.Step != step {
+		return nil, violation(models.RuleWrongAttackStep, "attack is in the %s step", attack.Step)
+	}
+	if err := h.validateNoEffectChoice(); err != nil {
+		return nil, err
+	}
+	return attack, nil
+}
+
+// resolveAttack compares powers or deals damage and clears the pending attack
+func (h *EventHandler) resolveAttack() error {
+	attack := h.game.GameState.PendingAttack
+	h.game.GameState.PendingAttack = nil
+	
+	attackerPlayer := attack.AttackerPlayer
+	defenderPlayer := h.context.GetOpponentPlayer(attackerPlayer)
+	attackerState := h.context.GetPlayerState(attackerPlayer)
+	defenderState := h.context.GetPlayerState(defenderPlayer)
+	
+	// The attacker may have left play during the block or counter window
+	attacker, exists := attackerState.BattleArea[attack.AttackerPos]
+	if !exists {
+		return nil
+	}
+	
+	defendingPos := attack.BlockerPos
+	if defendingPos == "" {
+		defendingPos = attack.TargetPos
+	}
+	
+	// Unblocked direct attack: damage goes to the defender's negative energy
+	if defendingPos == "" {
+		return h.dealBattleDamage(attackerPlayer, attacker.Instance(), h.getAttackDamage(attackerPlayer, attacker))
+	}
+	
+	if _, exists := defenderState.BattleArea[defendingPos]; !exists {
+		return nil
+	}
+	
+	attackPower := h.GetFriendPower(attackerPlayer, attack.AttackerPos)
+	defensePower := h.GetFriendPower(defenderPlayer, defendingPos)
+	
+	// The lower power friend is destroyed; on a tie both are destroyed
+	var destroyed []GameEvent
+	defender := defenderState.BattleArea[defendingPos]
+	if defensePower >= attackPower {
+		events, err := h.destroyFriend(attackerPlayer, attacker.InstanceID)
+		if err != nil {
+			return err
+		}
+		destroyed = append(destroyed, events...)
+	}
+	if attackPower >= defensePower {
+		events, err := h.destroyFriend(defenderPlayer, defender.InstanceID)
+		if err != nil {
+			return err
+		}
+		destroyed = append(destroyed, events...)
+	}
+	
+	for _, event := range destroyed {
+		if err := h.TriggerEvent(event); err != nil {
+			return err
+		}
+	}
+	
+	return nil
+}
+
+// getAttackDamage returns how many cards an unblocked attack moves to negative energy
+func (h *EventHandler) getAttackDamage(player int, attacker models.Friend) int {
+	damage := 1
+	
+	// Keep the point of view of any effect that is resolving
+	activePlayer, sourceID := h.context.ActivePlayer, h.context.SourceID
+	defer func() {
+		h.context.ActivePlayer, h.context.SourceID = activePlayer, sourceID
+	}()
+	
+	for _, effect := range h.getEffects(attacker.CardNo) {
+		modifier, ok := effect.(*effects.DamageModifierEffect)
+		if !ok {
+			continue
+		}
+		
+		card, err := h.loadCard(attacker.CardNo)
+		if err != nil {
+			return damage
+		}
+		h.context.ActivePlayer = player
+		h.context.SourceID = attacker.InstanceID
+		if modifier.CanActivate(h.context, card) {
+			damage += modifier.Amount
+		}
+	}
+	
+	return damage
+}
+
+// dealBattleDamage moves cards from the top of the defender's deck into their negative energy
+func (h *EventHandler) dealBattleDamage(attackerPlayer int, attacker models.CardInstance, amount int) error {
+	return h.dealDamage(h.context.GetOpponentPlayer(attackerPlayer), attackerPlayer, attacker, amount)
+}
+
+// destroyFriend moves a friend from the battle area to its owner's trash and
+// returns the events the destruction raises
+func (h *EventHandler) destroyFriend(player int, instanceID string) ([]GameEvent, error) {
+	transfer, err := h.transfer(ZoneMove{
+		Player:     player,
+		InstanceID: instanceID,
+		From:       models.ZoneBattleArea,
+		To:         models.ZoneTrash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(transfer.events, friendDestroyedEvent(player, transfer.fromPos, transfer.card, h.game.CurrentPhase)), nil
+}
+
+func friendDestroyedEvent(player int, pos string, friend models.CardInstance, phase models.GamePhase) GameEvent {
+	return GameEvent{
+		Type:       EventFriendDestroyed,
+		Player:     player,
+		CardNo:     friend.CardNo,
+		InstanceID: friend.InstanceID,
+		Target:     pos,
+		Phase:      phase,
+	}
+}
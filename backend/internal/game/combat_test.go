@@ -0,0 +1,183 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+	
+	"mememe-tcg/internal/models"
+)
+
+func TestResolveAttack(t *testing.T) {
+	tests := []struct {
+		name             string
+		attacker         string
+		blocker          string // Empty for an unblocked direct attack
+		attackerSurvives bool
+		blockerSurvives  bool
+		negativeEnergy   int
+	}{
+		{name: "stronger attacker destroys the blocker", attacker: "V-3000", blocker: "V-1000", attackerSurvives: true},
+		{name: "stronger blocker destroys the attacker", attacker: "V-1000", blocker: "V-3000", blockerSurvives: true},
+		{name: "equal power destroys both", attacker: "V-1000", blocker: "V-1000"},
+		{name: "direct attack deals damage", attacker: "V-1000", attackerSurvives: true, negativeEnergy: 1},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+			attacker := testCards[tt.attacker]
+			g.GameState.Player1State.BattleArea["0"] = models.Friend{InstanceID: "atk", CardNo: attacker.CardNo, Power: attacker.Power, TurnPlayed: 1}
+			if tt.blocker != "" {
+				blocker := testCards[tt.blocker]
+				g.GameState.Player2State.BattleArea["0"] = models.Friend{InstanceID: "blk", CardNo: blocker.CardNo, Power: blocker.Power, TurnPlayed: 2}
+			}
+			h := NewEventHandler(g, loadTestCard)
+	
+			if err := h.DeclareAttack(1, "0", ""); err != nil {
+				t.Fatalf("DeclareAttack: %v", err)
+			}
+			if tt.blocker != "" {
+				if err := h.DeclareBlock(2, "0"); err != nil {
+					t.Fatalf("DeclareBlock: %v", err)
+				}
+			} else if err := h.PassAttackStep(2); err != nil {
+				t.Fatalf("pass block step: %v", err)
+			}
+			if err := h.PassAttackStep(2); err != nil {
+				t.Fatalf("pass counter step: %v", err)
+			}
+	
+			if g.GameState.PendingAttack != nil {
+				t.Errorf("attack still pending: %+v", g.GameState.PendingAttack)
+			}
+			if _, ok := g.GameState.Player1State.BattleArea["0"]; ok != tt.attackerSurvives {
+				t.Errorf("attacker in play = %v, want %v", ok, tt.attackerSurvives)
+			}
+			if tt.blocker != "" {
+				if _, ok := g.GameState.Player2State.BattleArea["0"]; ok != tt.blockerSurvives {
+					t.Errorf("blocker in play = %v, want %v", ok, tt.blockerSurvives)
+				}
+			}
+			if got := len(g.GameState.Player2State.NegativeEnergy); got != tt.negativeEnergy {
+				t.Errorf("negative energy = %d, want %d", got, tt.negativeEnergy)
+			}
+		})
+	}
+}
+
+// F-034 activates itself when it blocks, which looks the blocker up by instance ID
+func TestBlockActivatesF034(t *testing.T) {
+	g := newTestGame()
+	g.GameState.Player1State.BattleArea["0"] = models.Friend{InstanceID: "atk", CardNo: "V-1000", Power: 1000, TurnPlayed: 1}
+	g.GameState.Player2State.BattleArea["3"] = models.Friend{InstanceID: "rokko", CardNo: "F-034", Power: 3000, TurnPlayed: 2}
+	h := NewEventHandler(g, loadTestCard)
+	
+	if err := h.DeclareAttack(1, "0", ""); err != nil {
+		t.Fatalf("DeclareAttack: %v", err)
+	}
+	if err := h.DeclareBlock(2, "3"); err != nil {
+		t.Fatalf("DeclareBlock: %v", err)
+	}
+	if g.GameState.Player2State.BattleArea["3"].IsRest {
+		t.Errorf("F-034 is still rested after blocking")
+	}
+	
+	if err := h.PassAttackStep(2); err != nil {
+		t.Fatalf("pass counter step: %v", err)
+	}
+	if _, ok := g.GameState.Player1State.BattleArea["0"]; ok {
+		t.Errorf("attacker survived a block by a stronger friend")
+	}
+}
+
+// The defender plays counter cards after the block step, and the attack resolves
+// with the power they give
+func TestCounterWindow(t *testing.T) {
+	g := newTestGame()
+	g.GameState.Player1State.BattleArea["0"] = models.Friend{InstanceID: "atk", CardNo: "V-3000", Power: 3000, TurnPlayed: 1}
+	g.GameState.Player2State.BattleArea["0"] = models.Friend{InstanceID: "blk", CardNo: "V-1000", Power: 1000, TurnPlayed: 2}
+	g.GameState.Player2State.Hand = []models.CardInstance{{InstanceID: "counter", CardNo: "F-065"}, {InstanceID: "support", CardNo: "V-S2"}, {InstanceID: "friend", CardNo: "V-1000"}}
+	h := NewEventHandler(g, loadTestCard)
+	
+	if err := h.DeclareAttack(1, "0", ""); err != nil {
+		t.Fatalf("DeclareAttack: %v", err)
+	}
+	if err := h.ValidatePlayCard(2, "counter", ""); ruleOf(err) != models.RuleWrongAttackStep {
+		t.Errorf("counter in the block step = %v, want rule %q", err, models.RuleWrongAttackStep)
+	}
+	if err := h.DeclareBlock(2, "0"); err != nil {
+		t.Fatalf("DeclareBlock: %v", err)
+	}
+	
+	for _, instanceID := range []string{"support", "friend"} {
+		if err := h.ValidatePlayCard(2, instanceID, ""); ruleOf(err) != models.RuleNotCounter {
+			t.Errorf("playing %s as a counter = %v, want rule %q", instanceID, err, models.RuleNotCounter)
+		}
+	}
+	if err := h.ValidatePlayCard(2, "counter", ""); err != nil {
+		t.Fatalf("ValidatePlayCard: %v", err)
+	}
+	
+	// Play the counter as the service does
+	if err := h.MoveCard(ZoneMove{Player: 2, InstanceID: "counter", From: models.ZoneHand, To: models.ZoneTrash}); err != nil {
+		t.Fatalf("MoveCard: %v", err)
+	}
+	if err := h.TriggerEvent(GameEvent{Type: EventSupportPlayed, Player: 2, CardNo: "F-065", InstanceID: "counter", Phase: g.CurrentPhase, Data: map[string]interface{}{"targets": []string{"blk"}}}); err != nil {
+		t.Fatalf("TriggerEvent: %v", err)
+	}
+	if got := h.GetFriendPower(2, "0"); got != 3000 {
+		t.Errorf("blocker power = %d, want 3000 after the counter", got)
+	}
+	if g.GameState.PendingAttack == nil || g.GameState.PendingAttack.Step != models.AttackStepCounter {
+		t.Fatalf("the counter window closed after one counter")
+	}
+	
+	if err := h.PassAttackStep(2); err != nil {
+		t.Fatalf("pass counter step: %v", err)
+	}
+	if _, ok := g.GameState.Player1State.BattleArea["0"]; ok {
+		t.Errorf("attacker survived a blocker of equal power")
+	}
+	if _, ok := g.GameState.Player2State.BattleArea["0"]; ok {
+		t.Errorf("blocker survived an attacker of equal power")
+	}
+}
+
+func TestPassAttackStepWithoutAttack(t *testing.T) {
+	h := NewEventHandler(newTestGame(), loadTestCard)
+	if err := h.PassAttackStep(2); ruleOf(err) != models.RuleNoAttack {
+		t.Errorf("PassAttackStep = %v, want rule %q", err, models.RuleNoAttack)
+	}
+}
+
+// F-016 deals 1 more damage while its controller has 3 or more face-up negative
+// energy cards. Checking it keeps the point of view of the effect that asked.
+func TestAttackDamageModifier(t *testing.T) {
+	tests := []struct {
+		name   string
+		faceUp int
+		damage int
+	}{
+		{name: "condition not met", faceUp: 2, damage: 1},
+		{name: "condition met", faceUp: 3, damage: 2},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+			attacker := models.Friend{InstanceID: "kurage", CardNo: "F-016", Power: 3000, TurnPlayed: 1}
+			for i := 0; i < tt.faceUp; i++ {
+				g.GameState.Player1State.NegativeEnergy = append(g.GameState.Player1State.NegativeEnergy, models.NegativeEnergyCard{InstanceID: fmt.Sprintf("n%d", i), CardNo: "V-1000", FaceUp: true})
+			}
+			h := NewEventHandler(g, loadTestCard)
+			
+			h.context.ActivePlayer, h.context.SourceID = 2, "asking"
+			if got := h.getAttackDamage(1, attacker); got != tt.damage {
+				t.Errorf("damage = %d, want %d", got, tt.damage)
+			}
+			if h.context.ActivePlayer != 2 || h.context.SourceID != "asking" {
+				t.Errorf("context changed to player %d, source %q", h.context.ActivePlayer, h.context.SourceID)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package game
+
+import (
+	
+	"mememe-tcg/internal/effects"
+	"mememe-tcg/internal/models"
+)
+
+// GetPlayCost returns the total cost a player pays to play a card, with cost
+// reductions from the player's cards in play applied
+func (h *EventHandler) GetPlayCost(player int, card *models.Card) int {
+	reduction, minCost := 0, 0
+	for _, src := range h.getCardSources() {
+		if src.player != player {
+			continue
+		}
+		
+		for _, effect := range h.getEffects(src.cardNo) {
+			costReduction, ok := effect.(*effects.CostReductionEffect)
+			if !ok || costReduction.CardType != card.Type {
+				continue
+			}
+			
+			// Reductions add up; the highest minimum of the applied reductions is kept
+			reduction += costReduction.Reduction
+			if costReduction.MinCost > minCost {
+				minCost = costReduction.MinCost
+			}
+		}
+	}
+	if reduction == 0 {
+		return card.Cost
+	}
+	
+	// Color symbols are never reduced, and the cost does not drop below the minimum
+	cost := card.Cost - reduction
+	if cost < minCost {
+		cost = minCost
+	}
+	if cost < colorCost(card) {
+		cost = colorCost(card)
+	}
+	if cost > card.Cost {
+		cost = card.Cost
+	}
+	return cost
+}
+
+// PayCost pays for a card by resting the selected energy cards and turning the
+// selected face-up negative energy cards face down. The selection must match
+// the total cost and cover every color symbol.
+func (h *EventHandler) PayCost(player int, card *models.Card, energyIndices []int, negEnergyIndices []int) error {
+	return h.payEnergy(player, card.CardNo, h.GetPlayCost(player, card), colorRequirements(card), energyIndices, negEnergyIndices)
+}
+
+// SelectPayment picks untapped energy cards, then face-up negative energy cards,
+// that pay for a card, covering the color symbols first. Returns false if the
+// player cannot pay.
+func (h *EventHandler) SelectPayment(player int, card *models.Card) ([]int, []int, bool) {
+	return h.selectEnergy(player, h.GetPlayCost(player, card), colorRequirements(card), true)
+}
+
+// payEnergy pays a cost of the given total and color symbols with the selected
+// energy cards and face-up negative energy cards
+func (h *EventHandler) payEnergy(player int, name string, cost int, requirements map[models.CardColor]int, energyIndices []int, negEnergyIndices []int) error {
+	playerState := h.context.GetPlayerState(player)
+	
+	if len(energyIndices)+len(negEnergyIndices) != cost {
+		return violation(models.RuleCannotPay, "%s costs %d but %d energy was selected", name, cost, len(energyIndices)+len(negEnergyIndices))
+	}
+	
+	selected := make(map[int]bool)
+	colors := make(map[models.CardColor]int)
+	for _, index := range energyIndices {
+		if index < 0 || index >= len(playerState.EnergyArea) {
+			return violation(models.RuleCannotPay, "no energy at index %d", index)
+		}
+		if selected[index] {
+			return violation(models.RuleCannotPay, "energy at index %d was selected twice", index)
+		}
+		if playerState.EnergyArea[index].IsRest {
+			return violation(models.RuleCannotPay, "energy at index %d is rested", index)
+		}
+		selected[index] = true
+		colors[playerState.EnergyArea[index].Color]++
+	}
+	
+	selectedNeg := make(map[int]bool)
+	for _, index := range negEnergyIndices {
+		if index < 0 || index >= len(playerState.NegativeEnergy) {
+			return violation(models.RuleCannotPay, "no negative energy at index %d", index)
+		}
+		if selectedNeg[index] {
+			return violation(models.RuleCannotPay, "negative energy at index %d was selected twice", index)
+		}
+		if !playerState.NegativeEnergy[index].FaceUp {
+			return violation(models.RuleCannotPay, "negative energy at index %d is face down", index)
+		}
+		selectedNeg[index] = true
+		colors[playerState.NegativeEnergy[index].Color]++
+	}
+	
+	for color, required := range requirements {
+		if colors[color] < required {
+			return violation(models.RuleCannotPay, "%s needs %d %s energy but %d was selected", name, required, color, colors[color])
+		}
+	}
+	
+	for _, index := range energyIndices {
+		playerState.EnergyArea[index].IsRest = true
+	}
+	return h.flipNegEnergyDown(player, negEnergyIndices)
+}
+
+// selectEnergy picks untapped energy cards for a cost, covering the color
+// symbols first. With useNegEnergy, face-up negative energy cards are picked
+// once energy cards run out. Returns false if the player cannot pay.
+func (h *EventHandler) selectEnergy(player int, cost int, requirements map[models.CardColor]int, useNegEnergy bool) ([]int, []int, bool) {
+	playerState := h.context.GetPlayerState(player)
+	
+	used := make(map[int]bool)
+	usedNeg := make(map[int]bool)
+	payment := make([]int, 0, cost)
+	negPayment := []int{}
+	colors := make(map[models.CardColor]int)
+	pick := func(color models.CardColor, count int) int {
+		for i, energy := range playerState.EnergyArea {
+			if count == 0 {
+				return 0
+			}
+			if energy.IsRest || used[i] || (color != "" && energy.Color != color) {
+				continue
+			}
+			used[i] = true
+			payment = append(payment, i)
+			colors[energy.Color]++
+			count--
+		}
+		if !useNegEnergy {
+			return count
+		}
+		for i, card := range playerState.NegativeEnergy {
+			if count == 0 {
+				return 0
+			}
+			if !card.FaceUp || usedNeg[i] || (color != "" && card.Color != color) {
+				continue
+			}
+			usedNeg[i] = true
+			negPayment = append(negPayment, i)
+			colors[card.Color]++
+			count--
+		}
+		return count
+	}
+	
+	for _, color := range []models.CardColor{models.ColorRed, models.ColorBlue, models.ColorYellow, models.ColorGreen} {
+		pick(color, requirements[color])
+	}
+	if pick("", cost-len(payment)-len(negPayment)) > 0 {
+		return nil, nil, false
+	}
+	
+	// The color picks may fall short while still filling the total
+	for color, required := range requirements {
+		if colors[color] < required {
+			return nil, nil, false
+		}
+	}
+	
+	return payment, negPayment, true
+}
+
+// colorRequirements returns the color symbols in a card's cost
+func colorRequirements(card *models.Card) map[models.CardColor]int {
+	return map[models.CardColor]int{
+		models.ColorRed:    card.CostRed,
+		models.ColorBlue:   card.CostBlue,
+		models.ColorYellow: card.CostYellow,
+		models.ColorGreen:  card.CostGreen,
+	}
+}
+
+func colorCost(card *models.Card) int {
+	return card.CostRed + card.CostBlue + card.CostYellow + card.CostGreen
+}
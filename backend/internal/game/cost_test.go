@@ -0,0 +1,166 @@
+package game
+
+import (
+	"testing"
+	
+	"mememe-tcg/internal/effects"
+	"mememe-tcg/internal/models"
+)
+
+func TestPayCost(t *testing.T) {
+	tests := []struct {
+		name           string
+		card           string
+		energy         []models.EnergyCard
+		negativeEnergy models.NegativeEnergyZone
+		pay            []int
+		payNegative    []int
+		rule           models.Rule // Empty when the payment is accepted
+	}{
+		{
+			name:   "exact payment with the color symbol",
+			card:   "V-3000",
+			energy: []models.EnergyCard{{InstanceID: "e1", Color: models.ColorGreen}, {InstanceID: "e2", Color: models.ColorRed}},
+			pay:    []int{0, 1},
+		},
+		{
+			name:   "underpaid",
+			card:   "V-3000",
+			energy: []models.EnergyCard{{InstanceID: "e1", Color: models.ColorGreen}, {InstanceID: "e2", Color: models.ColorRed}},
+			pay:    []int{0},
+			rule:   models.RuleCannotPay,
+		},
+		{
+			name:   "missing color symbol",
+			card:   "V-3000",
+			energy: []models.EnergyCard{{InstanceID: "e1", Color: models.ColorRed}, {InstanceID: "e2", Color: models.ColorRed}},
+			pay:    []int{0, 1},
+			rule:   models.RuleCannotPay,
+		},
+		{
+			name:   "rested energy",
+			card:   "V-1000",
+			energy: []models.EnergyCard{{InstanceID: "e1", Color: models.ColorRed, IsRest: true}},
+			pay:    []int{0},
+			rule:   models.RuleCannotPay,
+		},
+		{
+			name:   "same energy twice",
+			card:   "V-3000",
+			energy: []models.EnergyCard{{InstanceID: "e1", Color: models.ColorGreen}},
+			pay:    []int{0, 0},
+			rule:   models.RuleCannotPay,
+		},
+		{
+			name:           "face-up negative energy",
+			card:           "V-3000",
+			energy:         []models.EnergyCard{{InstanceID: "e1", Color: models.ColorRed}},
+			negativeEnergy: models.NegativeEnergyZone{{InstanceID: "n1", Color: models.ColorGreen, FaceUp: true}},
+			pay:            []int{0},
+			payNegative:    []int{0},
+		},
+		{
+			name:           "face-down negative energy",
+			card:           "V-3000",
+			energy:         []models.EnergyCard{{InstanceID: "e1", Color: models.ColorRed}},
+			negativeEnergy: models.NegativeEnergyZone{{InstanceID: "n1", Color: models.ColorGreen}},
+			pay:            []int{0},
+			payNegative:    []int{0},
+			rule:           models.RuleCannotPay,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+			playerState := &g.GameState.Player1State
+			playerState.EnergyArea = tt.energy
+			if tt.negativeEnergy != nil {
+				playerState.NegativeEnergy = tt.negativeEnergy
+			}
+			h := NewEventHandler(g, loadTestCard)
+	
+			err := h.PayCost(1, testCards[tt.card], tt.pay, tt.payNegative)
+			if tt.rule != "" {
+				if got := ruleOf(err); got != tt.rule {
+					t.Fatalf("PayCost error = %v (rule %q), want rule %q", err, got, tt.rule)
+				}
+				// A rejected payment changes nothing
+				for i, energy := range playerState.EnergyArea {
+					if energy.IsRest != tt.energy[i].IsRest {
+						t.Errorf("energy %d rest = %v after a rejected payment", i, energy.IsRest)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PayCost: %v", err)
+			}
+			for _, index := range tt.pay {
+				if !playerState.EnergyArea[index].IsRest {
+					t.Errorf("energy %d was not rested", index)
+				}
+			}
+			for _, index := range tt.payNegative {
+				if playerState.NegativeEnergy[index].FaceUp {
+					t.Errorf("negative energy %d is still face up", index)
+				}
+			}
+		})
+	}
+}
+
+// F-095 lowers the cost of friends by 1, down to 1 and never below their color symbols.
+// The reducer is a friend whose own reduction adds to it before either floor applies.
+func TestGetPlayCost(t *testing.T) {
+	tests := []struct {
+		name    string
+		card    string
+		field   bool
+		reducer string
+		cost    int
+	}{
+		{name: "no reduction", card: "V-5000", cost: 5},
+		{name: "reduced by 1", card: "V-5000", field: true, cost: 4},
+		{name: "reductions add up", card: "V-5000", field: true, reducer: "V-REDUCER", cost: 3},
+		{name: "not below 1", card: "V-1000", field: true, reducer: "V-REDUCER", cost: 1},
+		{name: "not below the color symbols", card: "V-R2", field: true, reducer: "V-REDUCER", cost: 2},
+		{name: "the highest minimum applies to the sum", card: "V-5000", field: true, reducer: "V-REDUCER-MIN4", cost: 4},
+		{name: "support cards are not reduced", card: "V-S2", field: true, cost: 2},
+	}
+	
+	registry := effects.NewEffectRegistry()
+	effects.InitializeEffects(registry)
+	registry.Register("V-REDUCER", &effects.CostReductionEffect{
+		BaseEffect: effects.BaseEffect{Trigger: effects.TriggerPersistent},
+		CardType:   models.CardTypeFriend,
+		Reduction:  1,
+		MinCost:    1,
+	})
+	registry.Register("V-REDUCER-MIN4", &effects.CostReductionEffect{
+		BaseEffect: effects.BaseEffect{Trigger: effects.TriggerPersistent},
+		CardType:   models.CardTypeFriend,
+		Reduction:  1,
+		MinCost:    4,
+	})
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+			if tt.field {
+				g.GameState.Player1State.FieldCard = &models.CardInstance{InstanceID: "univ", CardNo: "F-095"}
+				// The opponent's reductions never apply
+				g.GameState.Player2State.FieldCard = &models.CardInstance{InstanceID: "their-univ", CardNo: "F-095"}
+			}
+			if tt.reducer != "" {
+				g.GameState.Player1State.BattleArea["0"] = models.Friend{InstanceID: "reducer", CardNo: tt.reducer, Power: 1000, TurnPlayed: 1}
+			}
+			h := NewEventHandler(g, loadTestCard)
+			h.effectRegistry = registry
+			
+			if got := h.GetPlayCost(1, testCards[tt.card]); got != tt.cost {
+				t.Errorf("cost = %d, want %d", got, tt.cost)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	
+	"mememe-tcg/internal/effects"
+	"mememe-tcg/internal/models"
+)
+
+// RegisterDelayedTrigger stores an effect that happens at a later timing.
+// The trigger is kept in the game state, so it survives saving and reloading the game.
+func (h *EventHandler) RegisterDelayedTrigger(trigger models.DelayedTrigger) error {
+	switch trigger.Timing {
+	case models.TimingEndPhase, models.TimingTurnEnd:
+	default:
+		return fmt.Errorf("unknown delayed trigger timing: %s", trigger.Timing)
+	}
+	
+	if trigger.SourceID == "" {
+		trigger.SourceID = h.context.SourceID
+	}
+	trigger.Turn = h.game.CurrentTurn
+	
+	h.game.GameState.DelayedTriggers = append(h.game.GameState.DelayedTriggers, trigger)
+	return nil
+}
+
+// processDurations expires modifiers and fires delayed triggers whose timing is the given event:
+// the end of the main phase ends "until end phase" effects, the end of the end phase
+// fires end phase triggers, and the end of the turn ends "this turn" effects.
+func (h *EventHandler) processDurations(event GameEvent) error {
+	if h.game.GameState == nil {
+		return nil
+	}
+	
+	switch {
+	case event.Type == EventPhaseEnd && event.Phase == models.PhaseMain:
+		h.expirePowerModifiers(models.DurationUntilEndPhase)
+	case event.Type == EventPhaseEnd && event.Phase == models.PhaseEnd:
+		return h.fireDelayedTriggers(models.TimingEndPhase)
+	case event.Type == EventTurnEnd:
+		h.expirePowerModifiers(models.DurationTurn, models.DurationUntilEndPhase)
+		return h.fireDelayedTriggers(models.TimingTurnEnd)
+	}
+	return nil
+}
+
+// expirePowerModifiers removes the modifiers with any of the given durations
+func (h *EventHandler) expirePowerModifiers(durations ...models.EffectDuration) {
+	state := h.game.GameState
+	
+	modifiers := state.PowerModifiers[:0]
+	for _, modifier := range state.PowerModifiers {
+		expired := false
+		for _, duration := range durations {
+			if modifier.Duration == duration {
+				expired = true
+			}
+		}
+		if !expired {
+			modifiers = append(modifiers, modifier)
+		}
+	}
+	state.PowerModifiers = modifiers
+}
+
+// fireDelayedTriggers removes the triggers with the given timing and performs them
+// in the order they were registered. Triggers only fire during the turn they were
+// registered in; one whose timing had already passed in its turn is dropped.
+func (h *EventHandler) fireDelayedTriggers(timing models.DelayedTiming) error {
+	state := h.game.GameState
+	
+	var due []models.DelayedTrigger
+	remaining := state.DelayedTriggers[:0]
+	for _, trigger := range state.DelayedTriggers {
+		switch {
+		case trigger.Turn != h.game.CurrentTurn:
+			// Registered after its timing in an earlier turn
+		case trigger.Timing == timing:
+			due = append(due, trigger)
+		default:
+			remaining = append(remaining, trigger)
+		}
+	}
+	state.DelayedTriggers = remaining
+	
+	for _, trigger := range due {
+		if err := h.fireDelayedTrigger(trigger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fireDelayedTrigger performs one delayed trigger. A target that has already
+// left the zone the trigger refers to is skipped.
+func (h *EventHandler) fireDelayedTrigger(trigger models.DelayedTrigger) error {
+	activePlayer, sourceID := h.context.ActivePlayer, h.context.SourceID
+	defer func() {
+		h.context.ActivePlayer, h.context.SourceID = activePlayer, sourceID
+	}()
+	h.context.ActivePlayer = trigger.Player
+	h.context.SourceID = trigger.SourceID
+	
+	switch trigger.Action {
+	case models.DelayedDestroy:
+		err := h.DestroyFriend(trigger.Player, trigger.TargetID)
+		if errors.Is(err, ErrFriendNotFound) {
+			return nil
+		}
+		return err
+	}
+	return fmt.Errorf("unknown delayed trigger action: %s", trigger.Action)
+}
+
+// modifierActive reports whether a power modifier currently applies. Only
+// "while" modifiers can be inactive: their source must be in play and its
+// condition must hold.
+func (h *EventHandler) modifierActive(modifier models.PowerModifier) bool {
+	if modifier.Duration != models.DurationWhileCondition {
+		return true
+	}
+	
+	for _, src := range h.getCardSources() {
+		if src.instanceID != modifier.SourceID {
+			continue
+		}
+		
+		card, err := h.loadCard(src.cardNo)
+		if err != nil {
+			return false
+		}
+		
+		activePlayer, sourceID := h.context.ActivePlayer, h.context.SourceID
+		h.context.ActivePlayer, h.context.SourceID = src.player, src.instanceID
+		holds := true
+		for _, effect := range h.getEffects(src.cardNo) {
+			if condition, ok := effect.(effects.DurationCondition); ok && !condition.ConditionHolds(h.context, card) {
+				holds = false
+			}
+		}
+		h.context.ActivePlayer, h.context.SourceID = activePlayer, sourceID
+		return holds
+	}
+	return false
+}
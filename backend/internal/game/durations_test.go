@@ -0,0 +1,115 @@
+package game
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	
+	"mememe-tcg/internal/models"
+)
+
+// "Until the end phase" modifiers end when the main phase ends and "this turn"
+// modifiers when the turn ends. The discard choice holds the game in the end phase.
+func TestPowerModifierDurations(t *testing.T) {
+	g := newTestGame()
+	g.GameState.Player1State.BattleArea["0"] = models.Friend{InstanceID: "atk", CardNo: "V-1000", Power: 1000, TurnPlayed: 1}
+	for i := 0; i <= HandLimit; i++ {
+		g.GameState.Player1State.Hand = append(g.GameState.Player1State.Hand, models.CardInstance{InstanceID: fmt.Sprintf("h%d", i), CardNo: "V-1000"})
+	}
+	h := NewEventHandler(g, loadTestCard)
+	
+	for _, modifier := range []struct {
+		amount   int
+		duration models.EffectDuration
+	}{{1000, models.DurationUntilEndPhase}, {2000, models.DurationTurn}, {4000, models.DurationPermanent}} {
+		if err := h.ModifyPower(1, "atk", modifier.amount, modifier.duration); err != nil {
+			t.Fatalf("ModifyPower: %v", err)
+		}
+	}
+	if got := h.GetFriendPower(1, "0"); got != 8000 {
+		t.Errorf("power in the main phase = %d, want 8000", got)
+	}
+	
+	if err := h.AdvancePhase(); err != nil {
+		t.Fatalf("AdvancePhase: %v", err)
+	}
+	if g.CurrentPhase != models.PhaseEnd {
+		t.Fatalf("phase = %s, want the end phase", g.CurrentPhase)
+	}
+	if got := h.GetFriendPower(1, "0"); got != 7000 {
+		t.Errorf("power in the end phase = %d, want 7000", got)
+	}
+	
+	if err := h.ResolveDiscardChoice(1, []string{"h0"}); err != nil {
+		t.Fatalf("ResolveDiscardChoice: %v", err)
+	}
+	if got := h.GetFriendPower(1, "0"); got != 5000 {
+		t.Errorf("power on the next turn = %d, want 5000", got)
+	}
+}
+
+// Delayed triggers fire at their timing in the turn they were registered in
+func TestDelayedTriggers(t *testing.T) {
+	tests := []struct {
+		name      string
+		triggers  []models.DelayedTrigger
+		destroyed []string // Instance IDs of the friends destroyed by the end of the turn
+	}{
+		{
+			name:      "end phase",
+			triggers:  []models.DelayedTrigger{{Player: 1, Action: models.DelayedDestroy, TargetID: "a", Timing: models.TimingEndPhase}},
+			destroyed: []string{"a"},
+		},
+		{
+			name:      "turn end",
+			triggers:  []models.DelayedTrigger{{Player: 2, Action: models.DelayedDestroy, TargetID: "b", Timing: models.TimingTurnEnd}},
+			destroyed: []string{"b"},
+		},
+		{
+			name: "target already gone",
+			triggers: []models.DelayedTrigger{
+				{Player: 1, Action: models.DelayedDestroy, TargetID: "gone", Timing: models.TimingEndPhase},
+				{Player: 1, Action: models.DelayedDestroy, TargetID: "a", Timing: models.TimingEndPhase},
+			},
+			destroyed: []string{"a"},
+		},
+		{
+			name:      "registered in an earlier turn",
+			triggers:  []models.DelayedTrigger{{Player: 1, Action: models.DelayedDestroy, TargetID: "a", Timing: models.TimingEndPhase, Turn: 2}},
+			destroyed: []string{},
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+			g.GameState.Player1State.BattleArea["0"] = models.Friend{InstanceID: "a", CardNo: "V-1000", Power: 1000, TurnPlayed: 1}
+			g.GameState.Player2State.BattleArea["0"] = models.Friend{InstanceID: "b", CardNo: "V-1000", Power: 1000, TurnPlayed: 2}
+			h := NewEventHandler(g, loadTestCard)
+			for _, trigger := range tt.triggers {
+				if trigger.Turn != 0 {
+					g.GameState.DelayedTriggers = append(g.GameState.DelayedTriggers, trigger)
+				} else if err := h.RegisterDelayedTrigger(trigger); err != nil {
+					t.Fatalf("RegisterDelayedTrigger: %v", err)
+				}
+			}
+			
+			// The triggers survive saving and reloading the game
+			g = reload(t, g)
+			h = NewEventHandler(g, loadTestCard)
+			if err := h.AdvancePhase(); err != nil {
+				t.Fatalf("AdvancePhase: %v", err)
+			}
+			
+			destroyed := []string{}
+			destroyed = append(destroyed, instanceIDs(g.GameState.Player1State.Trash)...)
+			destroyed = append(destroyed, instanceIDs(g.GameState.Player2State.Trash)...)
+			if !reflect.DeepEqual(destroyed, tt.destroyed) {
+				t.Errorf("destroyed = %v, want %v", destroyed, tt.destroyed)
+			}
+			if len(g.GameState.DelayedTriggers) != 0 {
+				t.Errorf("triggers left after the turn: %+v", g.GameState.DelayedTriggers)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package game
 
 import (
 	"fmt"
-	"github.com/your-org/card_game/backend/internal/effects"
-	"github.com/your-org/card_game/backend/internal/models"
+	"time"
+	
+	"mememe-tcg/internal/effects"
+	"mememe-tcg/internal/models"
 )
 
 // EffectStack manages the resolution of effects in a Last-In-First-Out manner
@@ -15,9 +17,16 @@ type EffectStack struct {
 type EffectStackItem struct {
 	Effect   effects.Effect
 	Source   *models.Card
-	Targets  []effects.Target
+	Ability  int              // Index of the ability among the source card's abilities
+	SourceID string           // Instance ID of the source card
+	Targets  []effects.Target // Chosen when the item resolves if empty
 	Player   int
-	Priority int // Higher priority effects resolve first at the same stack level
+	Priority int              // Higher priority effects resolve first at the same stack level
+	Choices  [][]int          // Selections made so far for the effect's choices
+	Accepted *bool            // Whether the controller uses an optional effect, nil until asked
+	Replace  string           // Friend the controller picked to make room in a full battle area
+	CostPaid bool             // The ability's cost was paid when it was activated
+	Group    []EffectStackItem // Simultaneous effects of Player still to be ordered
 }
 
 // NewEffectStack creates a new effect stack
@@ -60,6 +69,9 @@ type EffectResolver struct {
 	stack        *EffectStack
 	context      *effects.GameContext
 	eventHandler *EventHandler
+	resolving    bool              // An effect's Apply is running
+	events       []GameEvent       // Events raised by the effect that is resolving
+	resume       models.ResumeStep // Game step to continue after a resumed stack, kept if it pauses again
 }
 
 // NewEffectResolver creates a new effect resolver
@@ -84,16 +96,70 @@ func (r *EffectResolver) QueueEffect(effect effects.Effect, source *models.Card,
 	
 	// Add to stack
 	r.stack.Push(EffectStackItem{
-		Effect:  effect,
-		Source:  source,
-		Targets: targets,
-		Player:  player,
+		Effect:   effect,
+		Source:   source,
+		SourceID: r.context.SourceID,
+		Targets:  targets,
+		Player:   player,
 	})
 	
 	return nil
 }
 
-// ResolveAll resolves all effects on the stack
+// Push puts simultaneously triggered effects on the stack. The active player's
+// effects resolve first, then the other player's. A player with several effects
+// orders them through an "order" choice when their group reaches the top.
+func (r *EffectResolver) Push(items []EffectStackItem) {
+	activePlayer := r.eventHandler.game.ActivePlayer
+	
+	ordered := make([]EffectStackItem, 0, 2)
+	for _, player := range []int{activePlayer, r.context.GetOpponentPlayer(activePlayer)} {
+		var own []EffectStackItem
+		for _, item := range items {
+			if item.Player == player {
+				own = append(own, item)
+			}
+		}
+		
+		switch len(own) {
+		case 0:
+		case 1:
+			ordered = append(ordered, own[0])
+		default:
+			ordered = append(ordered, EffectStackItem{Player: player, Group: own})
+		}
+	}
+	
+	// Effects triggered while resolution is paused wait until everything
+	// already on the stack has resolved
+	if pending := r.eventHandler.game.GameState.PendingEffect; pending != nil {
+		entries := make([]models.StackEntry, 0, len(ordered)+len(pending.Stack))
+		for i := len(ordered) - 1; i >= 0; i-- {
+			entries = append(entries, stackEntry(ordered[i]))
+		}
+		pending.Stack = append(entries, pending.Stack...)
+		return
+	}
+	
+	// The stack is LIFO, so the item that resolves first goes on last
+	for i := len(ordered) - 1; i >= 0; i-- {
+		r.stack.Push(ordered[i])
+	}
+}
+
+// Resolving reports whether an effect is being applied right now
+func (r *EffectResolver) Resolving() bool {
+	return r.resolving
+}
+
+// RecordEvent keeps an event raised by the resolving effect so its triggers
+// can be put on the stack once the effect has finished
+func (r *EffectResolver) RecordEvent(event GameEvent) {
+	r.events = append(r.events, event)
+}
+
+// ResolveAll resolves all effects on the stack. It stops early when an effect
+// needs a player's choice, leaving the rest of the stack in the game's pending effect.
 func (r *EffectResolver) ResolveAll() error {
 	for !r.stack.IsEmpty() {
 		item, ok := r.stack.Pop()
@@ -101,12 +167,89 @@ func (r *EffectResolver) ResolveAll() error {
 			break
 		}
 		
-		// Set active player for this effect
+		// Set the point of view for this effect
 		r.context.ActivePlayer = item.Player
+		r.context.SourceID = item.SourceID
 		
-		// Apply the effect
-		if err := item.Effect.Apply(r.context, item.Source, item.Targets); err != nil {
-			return fmt.Errorf("failed to apply effect %s: %w", item.Effect.GetDescription(), err)
+		// A group of simultaneous effects goes on the stack in the order its controller picks
+		if len(item.Group) > 0 {
+			if len(item.Choices) == 0 {
+				r.pause(item, r.eventHandler.interaction.RequestOrderSelection(item.Player, item.Group))
+				return nil
+			}
+			order := item.Choices[0]
+			for i := len(order) - 1; i >= 0; i-- {
+				r.stack.Push(item.Group[order[i]])
+			}
+			continue
+		}
+		
+		// The game may have changed since the effect triggered
+		if !item.Effect.CanActivate(r.context, item.Source) {
+			continue
+		}
+		
+		// The controller of an optional effect decides whether to use it
+		if optional, ok := item.Effect.(effects.OptionalAbility); ok && optional.IsOptional() {
+			if item.Accepted == nil {
+				r.pause(item, r.eventHandler.interaction.RequestConfirmation(item.Player, item.Effect.GetDescription(), optional.AcceptsByDefault()))
+				return nil
+			}
+			if !*item.Accepted {
+				continue
+			}
+		}
+		
+		var targets []effects.Target
+		if chooser, ok := item.Effect.(effects.ChoiceEffect); ok {
+			selected, choice := r.nextChoice(&item, chooser)
+			if choice != nil {
+				r.pause(item, r.eventHandler.interaction.RequestChoice(item.Player, choice))
+				return nil
+			}
+			targets = selected
+		} else {
+			selected, choice, ok := r.selectTargets(&item)
+			if choice != nil {
+				r.pause(item, *choice)
+				return nil
+			}
+			// Every target picked in advance has left, so the effect does nothing
+			if !ok {
+				continue
+			}
+			targets = selected
+		}
+		
+		// A friend entering a full battle area replaces one the controller picks
+		if choice := r.roomChoice(&item); choice != nil {
+			r.pause(item, *choice)
+			return nil
+		}
+		
+		// Apply the effect. An ability whose cost cannot be paid any more does nothing.
+		r.resolving = true
+		var err error
+		if r.payCost(&item) {
+			if err = r.makeRoom(item); err == nil {
+				err = item.Effect.Apply(r.context, item.Source, targets)
+			}
+		}
+		r.resolving = false
+		
+		if err != nil {
+			r.clear()
+			// An effect that ends the game (e.g. drawing from an empty deck) is not a failure
+			if CheckVictory(r.eventHandler.game) {
+				return nil
+			}
+			return fmt.Errorf("failed to apply effect for %s (%s): %w", item.Source.CardNo, item.SourceID, err)
+		}
+		
+		// Nothing else resolves once the game is over
+		if CheckVictory(r.eventHandler.game) {
+			r.clear()
+			return nil
 		}
 		
 		// Check for any triggered effects
@@ -117,126 +260,413 @@ func (r *EffectResolver) ResolveAll() error {
 	return nil
 }
 
-// checkTriggeredEffects checks if resolving an effect triggers any other effects
+// payCost pays the cost of a triggered ability with one ("〜することで") right
+// before it applies. Returns false if the cost cannot be paid.
+func (r *EffectResolver) payCost(item *EffectStackItem) bool {
+	if item.CostPaid {
+		return true
+	}
+	
+	abilities := r.eventHandler.effectRegistry.GetAbilities(item.Source.CardNo)
+	if item.Ability >= len(abilities) || abilities[item.Ability].Cost.IsFree() {
+		return true
+	}
+	
+	if err := r.eventHandler.payAbilityCost(item.Player, item.SourceID, abilities[item.Ability].Cost, nil); err != nil {
+		return false
+	}
+	item.CostPaid = true
+	return true
+}
+
+// roomChoice returns the choice of a friend to put in the trash when an effect
+// summons a friend into its controller's full battle area, or nil if there is
+// room or the friend was already picked
+func (r *EffectResolver) roomChoice(item *EffectStackItem) *models.EffectChoice {
+	summoner, ok := item.Effect.(effects.FriendSummoner)
+	if !ok || !summoner.SummonsFriend() || !r.eventHandler.BattleAreaFull(item.Player) {
+		return nil
+	}
+	
+	playerState := r.context.GetPlayerState(item.Player)
+	if _, ok := findFriend(playerState, item.Replace); ok {
+		return nil
+	}
+	item.Replace = ""
+	
+	var friends []effects.Target
+	for _, pos := range sortedPositions(playerState.BattleArea) {
+		friends = append(friends, effects.FriendTarget(item.Player, pos, playerState.BattleArea[pos]))
+	}
+	choice := r.eventHandler.interaction.RequestReplacement(item.Player, friends)
+	return &choice
+}
+
+// makeRoom puts the friend picked by roomChoice in the trash
+func (r *EffectResolver) makeRoom(item EffectStackItem) error {
+	if item.Replace == "" || !r.eventHandler.BattleAreaFull(item.Player) {
+		return nil
+	}
+	return r.eventHandler.MoveToTrash(item.Player, item.Replace, models.ZoneBattleArea)
+}
+
+// selectTargets returns the targets an effect without choices of its own applies to.
+// Targets picked when the card was played are used if they are still valid, and false
+// is returned if none are. Otherwise the controller is asked to pick when there are
+// more valid targets than the effect takes.
+func (r *EffectResolver) selectTargets(item *EffectStackItem) ([]effects.Target, *models.EffectChoice, bool) {
+	validTargets := NewTargetValidator(r.context).ValidTargets(item.Effect, item.Source)
+	
+	if len(item.Targets) > 0 {
+		picked := matchTargets(validTargets, item.Targets)
+		return picked, nil, len(picked) > 0
+	}
+	
+	spec := item.Effect.GetTargetSpec()
+	if spec.Max <= 0 || len(validTargets) <= spec.Max {
+		return validTargets, nil, true
+	}
+	
+	if len(item.Choices) == 0 {
+		choice := r.eventHandler.interaction.RequestTargetSelection(item.Player, validTargets, spec.Min, spec.Max, spec.Description)
+		return nil, &choice, true
+	}
+	
+	picked := []effects.Target{}
+	for _, idx := range item.Choices[0] {
+		if idx >= 0 && idx < len(validTargets) {
+			picked = append(picked, validTargets[idx])
+		}
+	}
+	return picked, nil, true
+}
+
+// nextChoice replays the selections already made for an effect and returns the
+// options picked so far, with the next choice to make or nil once it can apply.
+// Choices without any option are answered with an empty selection.
+func (r *EffectResolver) nextChoice(item *EffectStackItem, chooser effects.ChoiceEffect) ([]effects.Target, *effects.Choice) {
+	var answers [][]effects.Target
+	var selected []effects.Target
+	for {
+		choice := chooser.NextChoice(r.context, item.Source, answers)
+		if choice == nil {
+			return selected, nil
+		}
+		
+		answered := len(answers)
+		if answered == len(item.Choices) {
+			if len(choice.Options) > 0 {
+				return selected, choice
+			}
+			item.Choices = append(item.Choices, []int{})
+		}
+		
+		picked := []effects.Target{}
+		for _, idx := range item.Choices[answered] {
+			if idx >= 0 && idx < len(choice.Options) {
+				picked = append(picked, choice.Options[idx])
+			}
+		}
+		answers = append(answers, picked)
+		selected = append(selected, picked...)
+	}
+}
+
+// pause puts an item back on the stack and stores the stack in the game state
+// until the player answers the choice
+func (r *EffectResolver) pause(item EffectStackItem, choice models.EffectChoice) {
+	if item.Source != nil {
+		choice.CardNo = item.Source.CardNo
+	}
+	choice.SourceID = item.SourceID
+	choice.Deadline = time.Now().Add(EffectChoiceTimeout)
+	
+	r.stack.Push(item)
+	entries := make([]models.StackEntry, 0, r.stack.Size())
+	for _, stacked := range r.stack.items {
+		entries = append(entries, stackEntry(stacked))
+	}
+	
+	r.eventHandler.game.GameState.PendingEffect = &models.PendingEffect{
+		Choice: choice,
+		Stack:  entries,
+		Resume: r.resume,
+	}
+	r.stack = NewEffectStack()
+}
+
+// Resume loads the paused stack from the game state and resolves it
+func (r *EffectResolver) Resume() error {
+	pending := r.eventHandler.game.GameState.PendingEffect
+	if pending == nil {
+		return nil
+	}
+	r.eventHandler.game.GameState.PendingEffect = nil
+	
+	for _, entry := range pending.Stack {
+		if item, ok := r.stackItem(entry); ok {
+			r.stack.Push(item)
+		}
+	}
+	
+	r.resume = pending.Resume
+	defer func() {
+		r.resume = ""
+	}()
+	return r.ResolveAll()
+}
+
+// stackEntry converts a stack item to its stored form
+func stackEntry(item EffectStackItem) models.StackEntry {
+	entry := models.StackEntry{
+		Ability:  item.Ability,
+		SourceID: item.SourceID,
+		Player:   item.Player,
+		Targets:  item.Targets,
+		Choices:  item.Choices,
+		Accepted: item.Accepted,
+		Replace:  item.Replace,
+		CostPaid: item.CostPaid,
+	}
+	if len(entry.Targets) == 0 {
+		entry.Targets = nil
+	}
+	if item.Source != nil {
+		entry.CardNo = item.Source.CardNo
+	}
+	for _, grouped := range item.Group {
+		entry.Group = append(entry.Group, stackEntry(grouped))
+	}
+	return entry
+}
+
+// stackItem looks up the effect of a stored stack entry again
+func (r *EffectResolver) stackItem(entry models.StackEntry) (EffectStackItem, bool) {
+	item := EffectStackItem{
+		Ability:  entry.Ability,
+		SourceID: entry.SourceID,
+		Player:   entry.Player,
+		Targets:  entry.Targets,
+		Choices:  entry.Choices,
+		Accepted: entry.Accepted,
+		Replace:  entry.Replace,
+		CostPaid: entry.CostPaid,
+	}
+	
+	if len(entry.Group) > 0 {
+		for _, grouped := range entry.Group {
+			if groupedItem, ok := r.stackItem(grouped); ok {
+				item.Group = append(item.Group, groupedItem)
+			}
+		}
+		return item, len(item.Group) == len(entry.Group)
+	}
+	
+	abilities := r.eventHandler.effectRegistry.GetAbilities(entry.CardNo)
+	if entry.Ability < 0 || entry.Ability >= len(abilities) {
+		return item, false
+	}
+	card, err := r.eventHandler.loadCard(entry.CardNo)
+	if err != nil {
+		return item, false
+	}
+	
+	item.Effect = abilities[entry.Ability].Effect
+	item.Source = card
+	return item, true
+}
+
+// checkTriggeredEffects puts the abilities triggered by the events the resolved
+// effect raised on top of the stack, so they resolve before older items.
+// Triggers of earlier events resolve first.
 func (r *EffectResolver) checkTriggeredEffects(item EffectStackItem) {
-	// For example, if a friend was destroyed, check for "when destroyed" triggers
-	// This would require tracking what happened during effect resolution
+	events := r.events
+	r.events = nil
 	
-	// TODO: Implement trigger checking based on effect results
+	for i := len(events) - 1; i >= 0; i-- {
+		r.Push(r.eventHandler.collectTriggers(events[i]))
+	}
 }
 
-// InteractionController manages player interactions during effect resolution
-type InteractionController struct {
-	resolver     *EffectResolver
-	pendingChoices map[string]PendingChoice
+// clear drops everything waiting on the stack
+func (r *EffectResolver) clear() {
+	r.stack = NewEffectStack()
+	r.events = nil
 }
 
-// PendingChoice represents a choice waiting for player input
-type PendingChoice struct {
-	Player      int
-	ChoiceType  string // "target", "option", "order", etc.
-	Options     []interface{}
-	Required    bool
-	Description string
+// InteractionController manages player interactions during effect resolution.
+// Only one choice is asked at a time; it is kept in the game's pending effect.
+type InteractionController struct {
+	resolver *EffectResolver
 }
 
 // NewInteractionController creates a new interaction controller
 func NewInteractionController(resolver *EffectResolver) *InteractionController {
 	return &InteractionController{
-		resolver:       resolver,
-		pendingChoices: make(map[string]PendingChoice),
+		resolver: resolver,
 	}
 }
 
-// RequestTargetSelection requests the player to select targets
-func (c *InteractionController) RequestTargetSelection(player int, validTargets []effects.Target, minTargets, maxTargets int, description string) string {
-	choiceID := generateChoiceID()
-	
-	c.pendingChoices[choiceID] = PendingChoice{
+// RequestChoice turns a choice asked by an effect into a choice for the player
+func (c *InteractionController) RequestChoice(player int, choice *effects.Choice) models.EffectChoice {
+	if choice.Player != 0 {
+		player = choice.Player
+	}
+	if choice.Kind == models.ChoiceKindOption {
+		return c.RequestOptionSelection(player, choice.Options, choice.Description)
+	}
+	return c.RequestTargetSelection(player, choice.Options, choice.Min, choice.Max, choice.Description)
+}
+
+// RequestTargetSelection requests the player to select targets.
+// The first minTargets targets are picked if the player does not answer.
+func (c *InteractionController) RequestTargetSelection(player int, validTargets []effects.Target, minTargets, maxTargets int, description string) models.EffectChoice {
+	return models.EffectChoice{
+		ID:          c.generateChoiceID(),
 		Player:      player,
-		ChoiceType:  "target",
-		Options:     targetsToInterface(validTargets),
-		Required:    minTargets > 0,
+		Kind:        models.ChoiceKindTarget,
+		Options:     validTargets,
+		Min:         minTargets,
+		Max:         maxTargets,
+		Default:     firstIndices(minTargets),
 		Description: description,
 	}
-	
-	return choiceID
 }
 
-// RequestOptionSelection requests the player to select from options
-func (c *InteractionController) RequestOptionSelection(player int, options []string, description string) string {
-	choiceID := generateChoiceID()
-	
-	optionsInterface := make([]interface{}, len(options))
-	for i, opt := range options {
-		optionsInterface[i] = opt
+// RequestOptionSelection requests the player to select exactly one of the options
+func (c *InteractionController) RequestOptionSelection(player int, options []effects.Target, description string) models.EffectChoice {
+	return models.EffectChoice{
+		ID:          c.generateChoiceID(),
+		Player:      player,
+		Kind:        models.ChoiceKindOption,
+		Options:     options,
+		Min:         1,
+		Max:         1,
+		Default:     firstIndices(1),
+		Description: description,
+	}
+}
+
+// RequestConfirmation asks the controller of an optional effect whether to use it.
+// Option 0 uses the effect and option 1 declines it.
+func (c *InteractionController) RequestConfirmation(player int, description string, acceptByDefault bool) models.EffectChoice {
+	answer := []int{1}
+	if acceptByDefault {
+		answer = []int{0}
 	}
 	
-	c.pendingChoices[choiceID] = PendingChoice{
-		Player:      player,
-		ChoiceType:  "option",
-		Options:     optionsInterface,
-		Required:    true,
+	return models.EffectChoice{
+		ID:     c.generateChoiceID(),
+		Player: player,
+		Kind:   models.ChoiceKindConfirm,
+		Options: []models.Target{
+			effects.OptionTarget("accept", "使う"),
+			effects.OptionTarget("decline", "使わない"),
+		},
+		Min:         1,
+		Max:         1,
+		Default:     answer,
 		Description: description,
 	}
+}
+
+// RequestReplacement asks the player which friend to put in the trash to make
+// room for a friend entering their full battle area
+func (c *InteractionController) RequestReplacement(player int, friends []effects.Target) models.EffectChoice {
+	return models.EffectChoice{
+		ID:          c.generateChoiceID(),
+		Player:      player,
+		Kind:        models.ChoiceKindReplace,
+		Options:     friends,
+		Min:         1,
+		Max:         1,
+		Default:     firstIndices(1),
+		Description: "Your battle area is full. Choose a friend to put in the trash",
+	}
+}
+
+// RequestOrderSelection asks the player to order their simultaneous triggered effects.
+// The selection lists every option index once, in the order the effects resolve.
+func (c *InteractionController) RequestOrderSelection(player int, items []EffectStackItem) models.EffectChoice {
+	options := make([]models.Target, len(items))
+	for i, item := range items {
+		options[i] = effects.OptionTarget(item.SourceID, item.Effect.GetDescription())
+		options[i].CardNo = item.Source.CardNo
+	}
 	
-	return choiceID
+	return models.EffectChoice{
+		ID:          c.generateChoiceID(),
+		Player:      player,
+		Kind:        models.ChoiceKindOrder,
+		Options:     options,
+		Min:         len(items),
+		Max:         len(items),
+		Default:     firstIndices(len(items)),
+		Description: "Choose the order your effects resolve in",
+	}
 }
 
-// SubmitChoice submits a player's choice
-func (c *InteractionController) SubmitChoice(choiceID string, selection []int) error {
-	choice, exists := c.pendingChoices[choiceID]
-	if !exists {
-		return fmt.Errorf("choice not found")
+// SubmitChoice records a player's answer to the pending choice and resumes
+// resolving the effect stack
+func (c *InteractionController) SubmitChoice(player int, choiceID string, selection []int) error {
+	pending := c.resolver.eventHandler.game.GameState.PendingEffect
+	if pending == nil || pending.Choice.ID != choiceID {
+		return violation(models.RuleChoicePending, "choice %s is not pending", choiceID)
+	}
+	
+	choice := pending.Choice
+	if choice.Player != player {
+		return violation(models.RuleNotYourTurn, "it is not your choice")
 	}
 	
 	// Validate selection
-	if len(selection) == 0 && choice.Required {
-		return fmt.Errorf("selection required")
+	if len(selection) < choice.Min || len(selection) > choice.Max {
+		if choice.Min == choice.Max {
+			return violation(models.RuleInvalidTarget, "select exactly %d options", choice.Min)
+		}
+		return violation(models.RuleInvalidTarget, "select between %d and %d options", choice.Min, choice.Max)
 	}
 	
+	picked := make(map[int]bool)
 	for _, idx := range selection {
 		if idx < 0 || idx >= len(choice.Options) {
-			return fmt.Errorf("invalid selection index")
+			return violation(models.RuleInvalidTarget, "invalid selection index %d", idx)
 		}
-	}
-	
-	// Process the choice based on type
-	switch choice.ChoiceType {
-	case "target":
-		// Convert back to targets
-		selectedTargets := make([]effects.Target, len(selection))
-		for i, idx := range selection {
-			if target, ok := choice.Options[idx].(effects.Target); ok {
-				selectedTargets[i] = target
-			}
+		if picked[idx] {
+			return violation(models.RuleInvalidTarget, "option %d is selected twice", idx)
 		}
-		// TODO: Apply the selected targets to the pending effect
-		
-	case "option":
-		// Handle option selection
-		// TODO: Apply the selected option
+		picked[idx] = true
 	}
 	
-	// Remove the pending choice
-	delete(c.pendingChoices, choiceID)
+	// The choice belongs to the effect on top of the stack
+	top := &pending.Stack[len(pending.Stack)-1]
+	switch choice.Kind {
+	case models.ChoiceKindConfirm:
+		accepted := selection[0] == 0
+		top.Accepted = &accepted
+	case models.ChoiceKindReplace:
+		top.Replace = choice.Options[selection[0]].ID
+	default:
+		top.Choices = append(top.Choices, selection)
+	}
 	
-	return nil
+	return c.resolver.Resume()
 }
 
 // Helper functions
 
-func targetsToInterface(targets []effects.Target) []interface{} {
-	result := make([]interface{}, len(targets))
-	for i, target := range targets {
-		result[i] = target
+// firstIndices returns the indices of the first n options, in order
+func firstIndices(n int) []int {
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
 	}
-	return result
+	return indices
 }
 
-func generateChoiceID() string {
-	// TODO: Implement proper ID generation
-	return fmt.Sprintf("choice_%d", len(choices))
+// generateChoiceID numbers choices per game, so IDs never repeat within a game
+func (c *InteractionController) generateChoiceID() string {
+	state := c.resolver.eventHandler.game.GameState
+	state.ChoiceCount++
+	return fmt.Sprintf("choice_%d", state.ChoiceCount)
 }
-
-var choices = make(map[string]bool) // Temporary tracking
\ No newline at end of file
@@ -0,0 +1,200 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	
+	"mememe-tcg/internal/effects"
+	"mememe-tcg/internal/models"
+)
+
+// logEffect records the instance ID of each source it resolves for
+type logEffect struct {
+	effects.BaseEffect
+	log *[]string
+}
+
+func (e *logEffect) CanActivate(game *effects.GameContext, source *models.Card) bool {
+	return true
+}
+
+func (e *logEffect) GetTargets(game *effects.GameContext, source *models.Card) []effects.Target {
+	return nil
+}
+
+func (e *logEffect) Apply(game *effects.GameContext, source *models.Card, targets []effects.Target) error {
+	*e.log = append(*e.log, game.SourceID)
+	return nil
+}
+
+// newLogHandler returns a handler whose "V-LOG" cards record their resolution in log.
+// "V-MAY" cards do the same if their controller uses the optional effect.
+func newLogHandler(g *models.Game, log *[]string) *EventHandler {
+	registry := effects.NewEffectRegistry()
+	registry.Register("V-LOG", &logEffect{BaseEffect: effects.BaseEffect{Trigger: effects.TriggerOnPlay}, log: log})
+	registry.Register("V-MAY", &logEffect{BaseEffect: effects.BaseEffect{Trigger: effects.TriggerOnPlay, Optional: true}, log: log})
+	
+	h := NewEventHandler(g, loadTestCard)
+	h.effectRegistry = registry
+	return h
+}
+
+// logItem is a stack item for the "V-LOG" card with the instance ID
+func logItem(h *EventHandler, player int, instanceID string) EffectStackItem {
+	return EffectStackItem{
+		Effect:   h.effectRegistry.GetAbilities("V-LOG")[0].Effect,
+		Source:   testCards["V-LOG"],
+		SourceID: instanceID,
+		Player:   player,
+	}
+}
+
+// Effects put on the stack later resolve first
+func TestEffectStackResolvesNewestFirst(t *testing.T) {
+	var log []string
+	h := newLogHandler(newTestGame(), &log)
+	
+	for _, instanceID := range []string{"first", "second", "third"} {
+		h.resolver.Push([]EffectStackItem{logItem(h, 1, instanceID)})
+	}
+	if err := h.resolver.ResolveAll(); err != nil {
+		t.Fatalf("ResolveAll: %v", err)
+	}
+	
+	if want := []string{"third", "second", "first"}; !reflect.DeepEqual(log, want) {
+		t.Errorf("resolved %v, want %v", log, want)
+	}
+	if !h.resolver.stack.IsEmpty() {
+		t.Errorf("%d items left on the stack", h.resolver.stack.Size())
+	}
+}
+
+// Simultaneous effects resolve the active player's first. A player with several of
+// them picks their order, and the answer is given on a reloaded game.
+func TestSimultaneousEffectOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    map[int][]string // Instance IDs of each player's triggered effects
+		answers  [][]int          // Order choices, player 1's group first
+		resolved []string
+	}{
+		{name: "active player first", items: map[int][]string{1: {"mine"}, 2: {"theirs"}}, resolved: []string{"mine", "theirs"}},
+		{name: "ordered by the controller", items: map[int][]string{1: {"a", "b", "c"}}, answers: [][]int{{2, 0, 1}}, resolved: []string{"c", "a", "b"}},
+		{
+			name:     "both players order their own",
+			items:    map[int][]string{1: {"a", "b"}, 2: {"x", "y"}},
+			answers:  [][]int{{1, 0}, {0, 1}},
+			resolved: []string{"b", "a", "x", "y"},
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var log []string
+			g := newTestGame()
+			h := newLogHandler(g, &log)
+			
+			// The opponent's effects come first to show the order does not follow the input
+			var items []EffectStackItem
+			for _, player := range []int{2, 1} {
+				for _, instanceID := range tt.items[player] {
+					items = append(items, logItem(h, player, instanceID))
+				}
+			}
+			h.resolver.Push(items)
+			if err := h.resolver.ResolveAll(); err != nil {
+				t.Fatalf("ResolveAll: %v", err)
+			}
+			
+			for i, answer := range tt.answers {
+				pending := g.GameState.PendingEffect
+				if pending == nil || pending.Choice.Kind != models.ChoiceKindOrder {
+					t.Fatalf("answer %d: no order choice is pending", i)
+				}
+				var options []string
+				for _, option := range pending.Choice.Options {
+					options = append(options, option.ID)
+				}
+				if want := tt.items[pending.Choice.Player]; !reflect.DeepEqual(options, want) {
+					t.Errorf("answer %d: player %d orders %v, want their effects %v", i, pending.Choice.Player, options, want)
+				}
+				if pending.Choice.Min != len(answer) || pending.Choice.Max != len(answer) {
+					t.Errorf("answer %d: choice takes %d to %d options, want every effect", i, pending.Choice.Min, pending.Choice.Max)
+				}
+				if err := h.ResolveEffectChoice(pending.Choice.Player, pending.Choice.ID, answer[:1]); ruleOf(err) != models.RuleInvalidTarget {
+					t.Errorf("answer %d: a partial order = %v, want rule %q", i, err, models.RuleInvalidTarget)
+				}
+				
+				g = reload(t, g)
+				h = newLogHandler(g, &log)
+				if err := h.ResolveEffectChoice(pending.Choice.Player, pending.Choice.ID, answer); err != nil {
+					t.Fatalf("answer %d: %v", i, err)
+				}
+			}
+			
+			if g.GameState.PendingEffect != nil {
+				t.Fatalf("choice still pending: %+v", g.GameState.PendingEffect.Choice)
+			}
+			if !reflect.DeepEqual(log, tt.resolved) {
+				t.Errorf("resolved %v, want %v", log, tt.resolved)
+			}
+		})
+	}
+}
+
+// An optional effect asks its controller first and applies only if they use it.
+// A choice left unanswered declines it.
+func TestOptionalEffectConfirmation(t *testing.T) {
+	tests := []struct {
+		name     string
+		answer   []int // nil lets the choice expire
+		resolved []string
+	}{
+		{name: "use", answer: []int{0}, resolved: []string{"may"}},
+		{name: "decline", answer: []int{1}, resolved: []string{}},
+		{name: "no answer", resolved: []string{}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := []string{}
+			g := newTestGame()
+			h := newLogHandler(g, &log)
+			h.resolver.Push([]EffectStackItem{{
+				Effect:   h.effectRegistry.GetAbilities("V-MAY")[0].Effect,
+				Source:   testCards["V-MAY"],
+				SourceID: "may",
+				Player:   1,
+			}})
+			if err := h.resolver.ResolveAll(); err != nil {
+				t.Fatalf("ResolveAll: %v", err)
+			}
+			
+			pending := g.GameState.PendingEffect
+			if pending == nil || pending.Choice.Kind != models.ChoiceKindConfirm {
+				t.Fatalf("no confirmation is pending")
+			}
+			if len(log) != 0 {
+				t.Fatalf("the effect applied before its controller answered")
+			}
+			
+			g = reload(t, g)
+			h = newLogHandler(g, &log)
+			if tt.answer == nil {
+				if expired, err := h.ExpireEffectChoice(time.Now().Add(EffectChoiceTimeout + time.Second)); !expired || err != nil {
+					t.Fatalf("ExpireEffectChoice = %v, %v", expired, err)
+				}
+			} else if err := h.ResolveEffectChoice(1, pending.Choice.ID, tt.answer); err != nil {
+				t.Fatalf("ResolveEffectChoice: %v", err)
+			}
+			
+			if g.GameState.PendingEffect != nil {
+				t.Fatalf("choice still pending: %+v", g.GameState.PendingEffect.Choice)
+			}
+			if !reflect.DeepEqual(log, tt.resolved) {
+				t.Errorf("resolved %v, want %v", log, tt.resolved)
+			}
+		})
+	}
+}
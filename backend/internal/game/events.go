@@ -2,8 +2,8 @@ package game
 
 import (
 	"fmt"
-	"github.com/your-org/card_game/backend/internal/effects"
-	"github.com/your-org/card_game/backend/internal/models"
+	"mememe-tcg/internal/effects"
+	"mememe-tcg/internal/models"
 )
 
 // EventType represents the type of game event
@@ -21,164 +21,232 @@ const (
 	EventFieldPlayed     EventType = "field_played"
 	EventTurnStart       EventType = "turn_start"
 	EventTurnEnd         EventType = "turn_end"
+	
+	// Zone movements raised by effects
+	EventCardsDrawn        EventType = "cards_drawn"
+	EventFriendReturned    EventType = "friend_returned"
+	EventNegEnergyRevealed EventType = "neg_energy_revealed"
+	EventNegEnergyFlipped  EventType = "neg_energy_flipped"
+	EventCardTrashed       EventType = "card_trashed"
+	EventCardToDeck        EventType = "card_to_deck"
+	EventCardToHand        EventType = "card_to_hand"
+	EventEnergyAdded       EventType = "energy_added"
+	
+	// Raised for every card that changes zones, Data holds "from" and "to"
+	EventCardLeftZone    EventType = "card_left_zone"
+	EventCardEnteredZone EventType = "card_entered_zone"
 )
 
 // GameEvent represents an event that occurred in the game
 type GameEvent struct {
-	Type     EventType
-	Player   int
-	CardNo   string
-	Target   string
-	Phase    models.GamePhase
-	Data     map[string]interface{}
+	Type       EventType
+	Player     int
+	CardNo     string
+	InstanceID string // Instance of the card that caused the event
+	Target     string
+	Phase      models.GamePhase
+	Data       map[string]interface{}
 }
 
+// CardLoader looks up card data by card number
+type CardLoader func(cardNo string) (*models.Card, error)
+
 // EventHandler handles game events and triggers effects
 type EventHandler struct {
 	game           *models.Game
 	effectRegistry *effects.EffectRegistry
-	eventQueue     []GameEvent
 	context        *effects.GameContext
+	resolver       *EffectResolver
+	interaction    *InteractionController
+	cardLoader     CardLoader
 }
 
 // NewEventHandler creates a new event handler
-func NewEventHandler(game *models.Game) *EventHandler {
-	return &EventHandler{
+func NewEventHandler(game *models.Game, cardLoader CardLoader) *EventHandler {
+	h := &EventHandler{
 		game:           game,
 		effectRegistry: effects.GetGlobalRegistry(),
-		eventQueue:     make([]GameEvent, 0),
-		context:        createGameContext(game),
+		cardLoader:     cardLoader,
 	}
+	h.context = h.createGameContext()
+	h.resolver = NewEffectResolver(h.context, h)
+	h.interaction = NewInteractionController(h.resolver)
+	return h
 }
 
-// TriggerEvent adds an event to the queue and processes it
+// TriggerEvent processes an event and resolves the effects it triggers
 func (h *EventHandler) TriggerEvent(event GameEvent) error {
-	h.eventQueue = append(h.eventQueue, event)
+	// Nothing triggers once the game is over
+	if h.game.Status == models.StatusFinished {
+		return nil
+	}
+	
+	// Events raised while an effect resolves are checked for triggers once it has resolved
+	if h.resolver.Resolving() {
+		h.resolver.RecordEvent(event)
+		return nil
+	}
 	
 	// Process the event
-	return h.processEvent(event)
+	if err := h.processEvent(event); err != nil {
+		return err
+	}
+	
+	// Expire durations and fire delayed triggers that end with this event
+	if err := h.processDurations(event); err != nil {
+		return err
+	}
+	
+	CheckVictory(h.game)
+	return nil
 }
 
-// processEvent handles a single event and triggers relevant effects
+// processEvent puts the abilities triggered by an event on the effect stack and resolves them
 func (h *EventHandler) processEvent(event GameEvent) error {
-	var triggerType effects.TriggerType
+	h.resolver.Push(h.collectTriggers(event))
 	
-	switch event.Type {
-	case EventFriendPlayed:
-		triggerType = effects.TriggerOnPlay
-	case EventFriendAttacks:
-		triggerType = effects.TriggerOnAttack
-	case EventFriendBlocks:
-		triggerType = effects.TriggerOnBlock
-	case EventFriendDestroyed:
-		triggerType = effects.TriggerOnDestroy
-	case EventDamageDealt:
-		triggerType = effects.TriggerOnDamageDealt
-	case EventPhaseStart:
-		triggerType = effects.TriggerStartPhase
-	case EventPhaseEnd:
-		triggerType = effects.TriggerEndPhase
-	case EventSupportPlayed:
-		// Support cards can have main or counter triggers
-		if h.game.CurrentPhase == models.PhaseMain {
-			triggerType = effects.TriggerMain
-		} else {
-			triggerType = effects.TriggerCounter
-		}
-	default:
-		// No effects to trigger for this event type
+	if err := h.resolver.ResolveAll(); err != nil {
+		return err
+	}
+	
+	// Process persistent effects
+	h.processPersistentEffects()
+	
+	// No card's effect is resolving any more
+	h.context.SourceID = ""
+	
+	return nil
+}
+
+// collectTriggers returns the abilities that trigger on an event, in the order they resolve
+func (h *EventHandler) collectTriggers(event GameEvent) []EffectStackItem {
+	triggerType, ok := h.triggerTypeFor(event)
+	if !ok {
 		return nil
 	}
 	
-	// Get all cards that could trigger on this event
-	cardNos := h.effectRegistry.GetEffectsForTrigger(triggerType)
+	var items []EffectStackItem
 	
-	// Check each potential trigger
-	for _, cardNo := range cardNos {
-		// Check if this card is in play or relevant to the event
-		if h.isCardRelevant(cardNo, event) {
-			effect, _ := h.effectRegistry.GetEffect(cardNo)
-			
+	// Check each copy of each card that could trigger
+	for _, ability := range h.effectRegistry.GetEffectsForTrigger(triggerType) {
+		for _, src := range h.getTriggerSources(ability.CardNo, event) {
 			// Load card data
-			card, err := h.loadCard(cardNo)
+			card, err := h.loadCard(src.cardNo)
 			if err != nil {
 				continue
 			}
 			
-			// Update context with current player
-			h.context.ActivePlayer = event.Player
+			// Effects resolve from the point of view of the card's controller
+			h.context.ActivePlayer = src.player
+			h.context.SourceID = src.instanceID
 			
-			// Check if effect can activate
-			if effect.CanActivate(h.context, card) {
-				// Get targets
-				targets := effect.GetTargets(h.context, card)
+			if ability.Effect.CanActivate(h.context, card) {
+				item := EffectStackItem{
+					Effect:   ability.Effect,
+					Source:   card,
+					Ability:  ability.Index,
+					SourceID: src.instanceID,
+					Player:   src.player,
+				}
 				
-				// Apply effect
-				if err := effect.Apply(h.context, card, targets); err != nil {
-					return fmt.Errorf("failed to apply effect for %s: %w", cardNo, err)
+				// Targets picked when the card was played. Effects on every valid
+				// target find their targets when they resolve.
+				if ids, ok := event.Data["targets"].([]string); ok && src.instanceID == event.InstanceID && ability.Effect.GetTargetSpec().Max > 0 {
+					item.Targets, _ = pickTargets(NewTargetValidator(h.context).ValidTargets(ability.Effect, card), ids)
 				}
+				
+				items = append(items, item)
 			}
 		}
 	}
 	
-	// Process persistent effects
-	h.processPersistentEffects()
+	return items
+}
+
+// triggerTypeFor returns the trigger timing an event corresponds to
+func (h *EventHandler) triggerTypeFor(event GameEvent) (effects.TriggerType, bool) {
+	switch event.Type {
+	case EventFriendPlayed:
+		return effects.TriggerOnPlay, true
+	case EventFriendAttacks:
+		return effects.TriggerOnAttack, true
+	case EventFriendBlocks:
+		return effects.TriggerOnBlock, true
+	case EventFriendDestroyed:
+		return effects.TriggerOnDestroy, true
+	case EventDamageDealt:
+		return effects.TriggerOnDamageDealt, true
+	case EventPhaseStart:
+		return effects.TriggerStartPhase, true
+	case EventPhaseEnd:
+		return effects.TriggerEndPhase, true
+	case EventSupportPlayed:
+		// Support cards can have main or counter triggers
+		return h.supportTrigger(), true
+	case EventCardLeftZone:
+		if from, _ := event.Data["from"].(models.Zone); from.InPlay() {
+			return effects.TriggerOnLeavePlay, true
+		}
+	}
 	
-	return nil
+	// No effects to trigger for this event type
+	return "", false
 }
 
-// isCardRelevant checks if a card is relevant to the current event
-func (h *EventHandler) isCardRelevant(cardNo string, event GameEvent) bool {
-	// Check if the card triggered the event
-	if event.CardNo == cardNo {
-		return true
+// supportTrigger returns the timing a support card played now is used at:
+// 【カウンター】 in an attack's counter window, otherwise 【メイン】
+func (h *EventHandler) supportTrigger() effects.TriggerType {
+	if attack := h.game.GameState.PendingAttack; attack != nil && attack.Step == models.AttackStepCounter {
+		return effects.TriggerCounter
+	}
+	return effects.TriggerMain
+}
+
+// getTriggerSources returns the copies of a card whose abilities are relevant to the event
+func (h *EventHandler) getTriggerSources(cardNo string, event GameEvent) []cardSource {
+	// The card that caused the event triggers from wherever it is now
+	if event.InstanceID != "" && event.CardNo == cardNo {
+		return []cardSource{{player: event.Player, instanceID: event.InstanceID, cardNo: cardNo}}
 	}
 	
-	// Check if card is on the battlefield
-	if h.game.GameState != nil {
-		player1State := h.game.GameState.Player1State
-		player2State := h.game.GameState.Player2State
-		
-		// Check battle areas
-		for _, friend := range player1State.BattleArea {
-			if friend.CardNo == cardNo {
-				return true
-			}
-		}
-		for _, friend := range player2State.BattleArea {
-			if friend.CardNo == cardNo {
-				return true
-			}
-		}
-		
-		// Check field cards
-		if player1State.FieldCard != nil && *player1State.FieldCard == cardNo {
-			return true
+	switch event.Type {
+	case EventFriendPlayed, EventFriendAttacks, EventFriendBlocks, EventFriendDestroyed, EventSupportPlayed, EventCardLeftZone:
+		// "When this card..." triggers only fire for the card that caused the event
+		return nil
+	}
+	
+	var sources []cardSource
+	for _, src := range h.getCardSources() {
+		if src.cardNo != cardNo {
+			continue
 		}
-		if player2State.FieldCard != nil && *player2State.FieldCard == cardNo {
-			return true
+		// "When your friend deals damage" only fires for the attacking player's cards,
+		// and not for damage dealt by effects
+		if event.Type == EventDamageDealt && (event.InstanceID == "" || src.player != event.Player) {
+			continue
 		}
+		sources = append(sources, src)
 	}
-	
-	return false
+	return sources
 }
 
 // processPersistentEffects processes all active persistent effects
 func (h *EventHandler) processPersistentEffects() {
-	// Get all persistent effects
-	cardNos := h.effectRegistry.GetEffectsForTrigger(effects.TriggerPersistent)
-	
-	for _, cardNo := range cardNos {
-		if h.isCardOnField(cardNo) {
-			effect, _ := h.effectRegistry.GetEffect(cardNo)
-			card, err := h.loadCard(cardNo)
+	for _, src := range h.getCardSources() {
+		for _, ability := range h.effectRegistry.GetAbilities(src.cardNo) {
+			if ability.Trigger != effects.TriggerPersistent {
+				continue
+			}
+			
+			effect := ability.Effect
+			card, err := h.loadCard(src.cardNo)
 			if err != nil {
 				continue
 			}
 			
-			// Check which player controls this card
-			player := h.getCardController(cardNo)
-			h.context.ActivePlayer = player
+			// Persistent effects resolve for the player who controls the card
+			h.context.ActivePlayer = src.player
+			h.context.SourceID = src.instanceID
 			
 			if effect.CanActivate(h.context, card) {
 				targets := effect.GetTargets(h.context, card)
@@ -188,97 +256,32 @@ func (h *EventHandler) processPersistentEffects() {
 	}
 }
 
-// isCardOnField checks if a card is currently on the field
-func (h *EventHandler) isCardOnField(cardNo string) bool {
-	if h.game.GameState == nil {
-		return false
-	}
-	
-	// Check battle areas
-	for _, friend := range h.game.GameState.Player1State.BattleArea {
-		if friend.CardNo == cardNo {
-			return true
-		}
-	}
-	for _, friend := range h.game.GameState.Player2State.BattleArea {
-		if friend.CardNo == cardNo {
-			return true
-		}
-	}
-	
-	// Check field cards
-	if h.game.GameState.Player1State.FieldCard != nil && 
-	   *h.game.GameState.Player1State.FieldCard == cardNo {
-		return true
-	}
-	if h.game.GameState.Player2State.FieldCard != nil && 
-	   *h.game.GameState.Player2State.FieldCard == cardNo {
-		return true
-	}
-	
-	return false
-}
-
-// getCardController returns which player controls a card
-func (h *EventHandler) getCardController(cardNo string) int {
-	if h.game.GameState == nil {
-		return 0
-	}
-	
-	// Check player 1's cards
-	for _, friend := range h.game.GameState.Player1State.BattleArea {
-		if friend.CardNo == cardNo {
-			return 1
-		}
-	}
-	if h.game.GameState.Player1State.FieldCard != nil && 
-	   *h.game.GameState.Player1State.FieldCard == cardNo {
-		return 1
-	}
-	
-	// Check player 2's cards
-	for _, friend := range h.game.GameState.Player2State.BattleArea {
-		if friend.CardNo == cardNo {
-			return 2
-		}
-	}
-	if h.game.GameState.Player2State.FieldCard != nil && 
-	   *h.game.GameState.Player2State.FieldCard == cardNo {
-		return 2
-	}
-	
-	return 0
-}
-
-// loadCard loads card data (placeholder - should use actual card service)
+// loadCard loads card data through the handler's card loader
 func (h *EventHandler) loadCard(cardNo string) (*models.Card, error) {
-	// TODO: Load from card service/database
-	return &models.Card{
-		CardNo: cardNo,
-	}, nil
+	if h.cardLoader == nil {
+		return &models.Card{
+			CardNo: cardNo,
+		}, nil
+	}
+	return h.cardLoader(cardNo)
 }
 
 // createGameContext creates a game context for effects
-func createGameContext(game *models.Game) *effects.GameContext {
+func (h *EventHandler) createGameContext() *effects.GameContext {
+	game := h.game
+	
 	return &effects.GameContext{
 		Game: game,
 		
-		DrawCards: func(player int, count int) error {
-			// TODO: Implement
-			return nil
-		},
+		GetCard: h.loadCard,
 		
-		DestroyFriend: func(player int, cardNo string) error {
-			// TODO: Implement
-			return nil
-		},
+		DrawCards: h.DrawCards,
 		
-		ReturnToHand: func(player int, cardNo string) error {
-			// TODO: Implement
-			return nil
-		},
+		DestroyFriend: h.DestroyFriend,
+		
+		ReturnToHand: h.ReturnToHand,
 		
-		RestFriend: func(player int, cardNo string) error {
+		RestFriend: func(player int, instanceID string) error {
 			if game.GameState == nil {
 				return fmt.Errorf("no game state")
 			}
@@ -291,17 +294,17 @@ func createGameContext(game *models.Game) *effects.GameContext {
 			}
 			
 			for pos, friend := range playerState.BattleArea {
-				if friend.CardNo == cardNo {
+				if friend.InstanceID == instanceID {
 					friend.IsRest = true
 					playerState.BattleArea[pos] = friend
 					return nil
 				}
 			}
 			
-			return fmt.Errorf("friend not found")
+			return fmt.Errorf("%s: %w", instanceID, ErrFriendNotFound)
 		},
 		
-		ActiveFriend: func(player int, cardNo string) error {
+		ActiveFriend: func(player int, instanceID string) error {
 			if game.GameState == nil {
 				return fmt.Errorf("no game state")
 			}
@@ -314,17 +317,17 @@ func createGameContext(game *models.Game) *effects.GameContext {
 			}
 			
 			for pos, friend := range playerState.BattleArea {
-				if friend.CardNo == cardNo {
+				if friend.InstanceID == instanceID {
 					friend.IsRest = false
 					playerState.BattleArea[pos] = friend
 					return nil
 				}
 			}
 			
-			return fmt.Errorf("friend not found")
+			return fmt.Errorf("%s: %w", instanceID, ErrFriendNotFound)
 		},
 		
-		ModifyPower: func(player int, cardNo string, amount int) error {
+		ActiveEnergy: func(player int, instanceID string) error {
 			if game.GameState == nil {
 				return fmt.Errorf("no game state")
 			}
@@ -336,50 +339,42 @@ func createGameContext(game *models.Game) *effects.GameContext {
 				playerState = &game.GameState.Player2State
 			}
 			
-			for pos, friend := range playerState.BattleArea {
-				if friend.CardNo == cardNo {
-					friend.Power += amount
-					if friend.Power < 0 {
-						friend.Power = 0
-					}
-					playerState.BattleArea[pos] = friend
+			for i, energy := range playerState.EnergyArea {
+				if energy.InstanceID == instanceID {
+					playerState.EnergyArea[i].IsRest = false
 					return nil
 				}
 			}
 			
-			return fmt.Errorf("friend not found")
+			return fmt.Errorf("%s in energy area: %w", instanceID, ErrCardNotFound)
 		},
 		
-		RevealNegEnergy: func(player int, count int) error {
-			// TODO: Implement - need to track revealed state
-			return nil
-		},
+		ModifyPower: h.ModifyPower,
 		
-		PlaceFieldCard: func(player int, cardNo string) error {
-			// TODO: Implement
-			return nil
-		},
+		RevealNegEnergy: h.RevealNegEnergy,
 		
-		MoveToTrash: func(player int, cardNo string, from string) error {
-			// TODO: Implement
-			return nil
-		},
+		PlaceFieldCard: h.PlaceFieldCard,
 		
-		MoveCardToDeck: func(player int, cardNo string, position string) error {
-			// TODO: Implement
-			return nil
-		},
+		MoveToTrash: h.MoveToTrash,
 		
-		DealDamage: func(player int, amount int) error {
-			// TODO: Implement
-			return nil
-		},
+		DiscardFromDeckTop: h.DiscardFromDeckTop,
+		
+		MoveCardToDeck: h.MoveCardToDeck,
+		
+		AddToHand: h.AddToHand,
 		
-		AddToEnergyArea: func(player int, cardNo string) error {
-			// TODO: Implement
-			return nil
+		DealDamage: h.DealDamage,
+		
+		AddToEnergyArea: h.AddToEnergyArea,
+		
+		SummonFriend: h.SummonFriend,
+		
+		MoveCard: func(player int, instanceID string, from, to models.Zone) error {
+			return h.MoveCard(ZoneMove{Player: player, InstanceID: instanceID, From: from, To: to})
 		},
 		
+		RegisterDelayedTrigger: h.RegisterDelayedTrigger,
+		
 		GetPlayerState: func(player int) *models.PlayerState {
 			if game.GameState == nil {
 				return nil
@@ -391,6 +386,14 @@ func createGameContext(game *models.Game) *effects.GameContext {
 			return &game.GameState.Player2State
 		},
 		
+		GetFriendPower: func(player int, instanceID string) int {
+			pos, ok := findFriend(h.context.GetPlayerState(player), instanceID)
+			if !ok {
+				return 0
+			}
+			return h.GetFriendPower(player, pos)
+		},
+		
 		GetOpponentPlayer: func(player int) int {
 			if player == 1 {
 				return 2
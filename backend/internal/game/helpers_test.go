@@ -0,0 +1,98 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	
+	"mememe-tcg/internal/models"
+)
+
+// testCards is the card data the tests load. Cards starting with "V-" have no effects
+// unless a test registers some.
+var testCards = map[string]*models.Card{
+	"V-1000": {CardNo: "V-1000", Type: models.CardTypeFriend, Color: models.ColorRed, Cost: 1, CostRed: 1, Power: 1000},
+	"V-3000": {CardNo: "V-3000", Type: models.CardTypeFriend, Color: models.ColorGreen, Cost: 2, CostGreen: 1, Power: 3000},
+	"F-034":  {CardNo: "F-034", Type: models.CardTypeFriend, Color: models.ColorBlue, Cost: 3, Power: 3000},
+	"F-042":  {CardNo: "F-042", Type: models.CardTypeFriend, Color: models.ColorYellow, Cost: 2, Power: 2000},
+	"F-015":  {CardNo: "F-015", Type: models.CardTypeFriend, Color: models.ColorGreen, Cost: 1, Power: 4000},
+	"V-5000": {CardNo: "V-5000", Type: models.CardTypeFriend, Color: models.ColorRed, Cost: 5, CostRed: 1, Power: 5000},
+	"V-R2":   {CardNo: "V-R2", Type: models.CardTypeFriend, Color: models.ColorRed, Cost: 3, CostRed: 2, Power: 3000},
+	"V-HAND": {CardNo: "V-HAND", Type: models.CardTypeFriend, Color: models.ColorRed, Cost: 1, Power: 1000},
+	"V-LOG":  {CardNo: "V-LOG", Type: models.CardTypeFriend, Color: models.ColorRed, Cost: 1, Power: 1000},
+	"V-MAY":  {CardNo: "V-MAY", Type: models.CardTypeFriend, Color: models.ColorRed, Cost: 1, Power: 1000},
+	"V-MAIN": {CardNo: "V-MAIN", Type: models.CardTypeFriend, Color: models.ColorRed, Cost: 1, Power: 1000},
+	"V-RISE": {CardNo: "V-RISE", Type: models.CardTypeSupport, Color: models.ColorRed, Cost: 1},
+	"F-016":  {CardNo: "F-016", Type: models.CardTypeFriend, Color: models.ColorGreen, Cost: 2, Power: 3000},
+	"F-025":  {CardNo: "F-025", Type: models.CardTypeFriend, Color: models.ColorRed, Cost: 2, Power: 2000},
+	"F-065":  {CardNo: "F-065", Type: models.CardTypeSupport, Color: models.ColorRed, Cost: 1, IsMainCounter: true},
+	"F-093":  {CardNo: "F-093", Type: models.CardTypeField, Color: models.ColorBlue, Cost: 1},
+	"F-095":  {CardNo: "F-095", Type: models.CardTypeField, Color: models.ColorYellow, Cost: 1},
+	"V-S2":   {CardNo: "V-S2", Type: models.CardTypeSupport, Color: models.ColorRed, Cost: 2},
+	"V-C1":   {CardNo: "V-C1", Type: models.CardTypeSupport, Color: models.ColorRed, Cost: 1, IsCounter: true},
+	"V-S4":   {CardNo: "V-S4", Type: models.CardTypeSupport, Color: models.ColorRed, Cost: 4},
+}
+
+func loadTestCard(cardNo string) (*models.Card, error) {
+	if card, ok := testCards[cardNo]; ok {
+		return card, nil
+	}
+	return nil, fmt.Errorf("%s: %w", cardNo, ErrCardNotFound)
+}
+
+// newTestGame returns a game in player 1's main phase on turn 3, with a few
+// cards in each deck and nothing else in play
+func newTestGame() *models.Game {
+	deck := func(player int) []models.CardInstance {
+		var cards []models.CardInstance
+		for i := 1; i <= 5; i++ {
+			cards = append(cards, models.CardInstance{InstanceID: fmt.Sprintf("p%d-d%d", player, i), CardNo: "V-1000"})
+		}
+		return cards
+	}
+	
+	player1 := NewPlayerState(deck(1))
+	player2 := NewPlayerState(deck(2))
+	return &models.Game{
+		GameID:       "test",
+		Player1ID:    1,
+		Player2ID:    2,
+		CurrentTurn:  3,
+		CurrentPhase: models.PhaseMain,
+		ActivePlayer: 1,
+		Status:       models.StatusPlaying,
+		GameState:    &models.GameState{Player1State: player1, Player2State: player2},
+	}
+}
+
+// reload round-trips a game through JSON, as the service does between requests
+func reload(t *testing.T, gameModel *models.Game) *models.Game {
+	t.Helper()
+	data, err := json.Marshal(gameModel)
+	if err != nil {
+		t.Fatalf("marshal game: %v", err)
+	}
+	var loaded models.Game
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("unmarshal game: %v", err)
+	}
+	return &loaded
+}
+
+// ruleOf returns the rule an error breaks, or "" if it is not a rule violation
+func ruleOf(err error) models.Rule {
+	var violation *models.RuleViolation
+	if errors.As(err, &violation) {
+		return violation.Rule
+	}
+	return ""
+}
+
+func instanceIDs(cards []models.CardInstance) []string {
+	ids := []string{}
+	for _, card := range cards {
+		ids = append(ids, card.InstanceID)
+	}
+	return ids
+}
@@ -0,0 +1,205 @@
+package game
+
+import (
+	"mememe-tcg/internal/effects"
+	"mememe-tcg/internal/models"
+)
+
+// LegalActions lists everything a player can do in the current game state
+type LegalActions struct {
+	Player        int                 `json:"player"`
+	CanMulligan   bool                `json:"can_mulligan"`
+	PhaseChoice   *models.PhaseChoice `json:"phase_choice,omitempty"` // Decision the player must make before anything else
+	EffectChoice  *models.EffectChoice `json:"effect_choice,omitempty"` // Choice a resolving effect is waiting for
+	PlayableCards []PlayableCard      `json:"playable_cards"`
+	Attacks       []AttackOption      `json:"attacks"`
+	Abilities     []AbilityOption     `json:"abilities"` // 【メイン】 abilities of friends and field cards in play
+	Blockers      []string            `json:"blockers"`
+	Counters      []PlayableCard      `json:"counters"` // Counter cards the defender can play in the counter window
+	CanPass       bool                `json:"can_pass"`
+	CanEndPhase   bool                `json:"can_end_phase"`
+}
+
+// PlayableCard is a card in hand that can be played along with one way to pay for it
+type PlayableCard struct {
+	InstanceID     string   `json:"instance_id"`
+	CardNo         string   `json:"card_no"`
+	Cost           int      `json:"cost"`
+	Energy         []int    `json:"energy"`              // Suggested energy indices to rest as payment
+	NegativeEnergy []int    `json:"negative_energy"`     // Suggested face-up negative energy indices to turn face down as payment
+	Positions      []string `json:"positions,omitempty"` // Battle area positions a friend can enter
+	Replace        bool     `json:"replace,omitempty"`   // The battle area is full: entering a position puts the friend there in the trash
+}
+
+// AttackOption is a friend that can attack and the targets it can choose.
+// "player" is a direct attack on the opponent.
+type AttackOption struct {
+	AttackerPos string   `json:"attacker_pos"`
+	Targets     []string `json:"targets"`
+}
+
+// AbilityOption is an ability of a card in play that can be activated now,
+// along with one way to pay for its energy cost
+type AbilityOption struct {
+	Position   string              `json:"position"` // Battle area position, or "field" for the field card
+	InstanceID string              `json:"instance_id"`
+	CardNo     string              `json:"card_no"`
+	Ability    int                 `json:"ability"`
+	Cost       effects.AbilityCost `json:"cost"`
+	Energy     []int               `json:"energy"` // Suggested energy indices to rest as payment
+}
+
+// GetLegalActions enumerates the actions available to a player
+func (h *EventHandler) GetLegalActions(player int) *LegalActions {
+	actions := &LegalActions{
+		Player:        player,
+		PlayableCards: []PlayableCard{},
+		Attacks:       []AttackOption{},
+		Abilities:     []AbilityOption{},
+		Blockers:      []string{},
+		Counters:      []PlayableCard{},
+	}
+	
+	playerState := h.context.GetPlayerState(player)
+	if playerState == nil {
+		return actions
+	}
+	
+	switch h.game.Status {
+	case models.StatusMulligan:
+		actions.CanMulligan = !playerState.MulliganDecided
+		return actions
+	case models.StatusPlaying:
+	default:
+		return actions
+	}
+	
+	// Nothing else can happen until the effect that is resolving gets its choice
+	if pending := h.game.GameState.PendingEffect; pending != nil {
+		if pending.Choice.Player == player {
+			actions.EffectChoice = &pending.Choice
+		}
+		return actions
+	}
+	
+	if choice := h.game.GameState.PendingPhaseChoice; choice != nil {
+		if choice.Player == player {
+			actions.PhaseChoice = choice
+		}
+		return actions
+	}
+	
+	if attack := h.game.GameState.PendingAttack; attack != nil {
+		if attack.AttackerPlayer == player {
+			return actions
+		}
+		actions.CanPass = true
+		
+		switch attack.Step {
+		case models.AttackStepBlock:
+			for _, pos := range sortedPositions(playerState.BattleArea) {
+				if h.ValidateBlock(player, pos) == nil {
+					actions.Blockers = append(actions.Blockers, pos)
+				}
+			}
+		case models.AttackStepCounter:
+			actions.Counters = h.getPlayableCards(player, func(card *models.Card) bool {
+				return card.Type == models.CardTypeSupport && (card.IsCounter || card.IsMainCounter)
+			})
+		}
+		return actions
+	}
+	
+	if player != h.game.ActivePlayer {
+		return actions
+	}
+	actions.CanEndPhase = h.ValidateEndPhase(player) == nil
+	
+	if h.game.CurrentPhase != models.PhaseMain {
+		return actions
+	}
+	
+	// Counter-only support cards cannot be played in the main phase
+	actions.PlayableCards = h.getPlayableCards(player, func(card *models.Card) bool {
+		return card.Type != models.CardTypeSupport || !card.IsCounter || card.IsMainCounter
+	})
+	
+	actions.Abilities = h.getActivatableAbilities(player)
+	
+	oppState := h.context.GetPlayerState(h.context.GetOpponentPlayer(player))
+	for _, pos := range sortedPositions(playerState.BattleArea) {
+		if h.ValidateAttack(player, pos, "") != nil {
+			continue
+		}
+		
+		option := AttackOption{AttackerPos: pos, Targets: []string{"player"}}
+		option.Targets = append(option.Targets, sortedPositions(oppState.BattleArea)...)
+		actions.Attacks = append(actions.Attacks, option)
+	}
+	
+	return actions
+}
+
+// getPlayableCards returns the hand cards that pass the filter and can be paid for
+func (h *EventHandler) getPlayableCards(player int, filter func(card *models.Card) bool) []PlayableCard {
+	playable := []PlayableCard{}
+	
+	for _, instance := range h.context.GetPlayerState(player).Hand {
+		card, err := h.loadCard(instance.CardNo)
+		if err != nil || !filter(card) {
+			continue
+		}
+		
+		energy, negEnergy, ok := h.SelectPayment(player, card)
+		if !ok {
+			continue
+		}
+		
+		option := PlayableCard{
+			InstanceID:     instance.InstanceID,
+			CardNo:         instance.CardNo,
+			Cost:           h.GetPlayCost(player, card),
+			Energy:         energy,
+			NegativeEnergy: negEnergy,
+		}
+		if card.Type == models.CardTypeFriend {
+			option.Positions, option.Replace = h.entryPositions(player)
+		}
+		playable = append(playable, option)
+	}
+	
+	return playable
+}
+
+// getActivatableAbilities returns the 【メイン】 abilities of the player's cards in play that can be used now
+func (h *EventHandler) getActivatableAbilities(player int) []AbilityOption {
+	options := []AbilityOption{}
+	playerState := h.context.GetPlayerState(player)
+	
+	for _, src := range h.getCardSources() {
+		if src.player != player {
+			continue
+		}
+		
+		position := "field"
+		if pos, ok := findFriend(playerState, src.instanceID); ok {
+			position = pos
+		}
+		
+		for _, ability := range h.effectRegistry.GetAbilities(src.cardNo) {
+			if h.ValidateActivateAbility(player, src.instanceID, ability.Index) != nil {
+				continue
+			}
+			energy, _, _ := h.selectEnergy(player, ability.Cost.Energy, nil, false)
+			options = append(options, AbilityOption{
+				Position:   position,
+				InstanceID: src.instanceID,
+				CardNo:     src.cardNo,
+				Ability:    ability.Index,
+				Cost:       ability.Cost,
+				Energy:     energy,
+			})
+		}
+	}
+	return options
+}
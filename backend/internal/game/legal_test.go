@@ -0,0 +1,131 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+	
+	"mememe-tcg/internal/models"
+)
+
+func playableIDs(cards []PlayableCard) []string {
+	ids := []string{}
+	for _, card := range cards {
+		ids = append(ids, card.InstanceID)
+	}
+	return ids
+}
+
+func TestGetLegalActionsMainPhase(t *testing.T) {
+	g := newTestGame()
+	playerState := &g.GameState.Player1State
+	playerState.EnergyArea = []models.EnergyCard{{InstanceID: "e1", Color: models.ColorRed}, {InstanceID: "e2", Color: models.ColorRed}}
+	playerState.Hand = []models.CardInstance{
+		{InstanceID: "friend", CardNo: "V-1000"},
+		{InstanceID: "wrong-color", CardNo: "V-3000"},
+		{InstanceID: "too-expensive", CardNo: "V-S4"},
+		{InstanceID: "counter", CardNo: "V-C1"},
+		{InstanceID: "main-counter", CardNo: "F-065"},
+	}
+	playerState.BattleArea["0"] = models.Friend{InstanceID: "ready", CardNo: "V-1000", Power: 1000, TurnPlayed: 1}
+	playerState.BattleArea["1"] = models.Friend{InstanceID: "new", CardNo: "V-1000", Power: 1000, TurnPlayed: 3}
+	playerState.BattleArea["2"] = models.Friend{InstanceID: "rested", CardNo: "V-1000", Power: 1000, TurnPlayed: 1, IsRest: true}
+	g.GameState.Player2State.BattleArea["4"] = models.Friend{InstanceID: "theirs", CardNo: "V-1000", Power: 1000, TurnPlayed: 2}
+	h := NewEventHandler(g, loadTestCard)
+	
+	actions := h.GetLegalActions(1)
+	if got, want := playableIDs(actions.PlayableCards), []string{"friend", "main-counter"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("playable cards = %v, want %v", got, want)
+	}
+	if len(actions.PlayableCards) > 0 {
+		friend := actions.PlayableCards[0]
+		if friend.Cost != 1 || !reflect.DeepEqual(friend.Energy, []int{0}) {
+			t.Errorf("friend costs %d paid with %v, want 1 paid with [0]", friend.Cost, friend.Energy)
+		}
+		if len(friend.Positions) != 7 || friend.Replace {
+			t.Errorf("friend enters %v (replace %v), want the 7 free positions", friend.Positions, friend.Replace)
+		}
+	}
+	wantAttacks := []AttackOption{{AttackerPos: "0", Targets: []string{"player", "4"}}}
+	if !reflect.DeepEqual(actions.Attacks, wantAttacks) {
+		t.Errorf("attacks = %+v, want %+v", actions.Attacks, wantAttacks)
+	}
+	if !actions.CanEndPhase || actions.CanPass || actions.CanMulligan {
+		t.Errorf("end phase %v, pass %v, mulligan %v; want only end phase", actions.CanEndPhase, actions.CanPass, actions.CanMulligan)
+	}
+	
+	// The opponent can do nothing in player 1's main phase
+	opponent := h.GetLegalActions(2)
+	if len(opponent.PlayableCards) != 0 || len(opponent.Attacks) != 0 || opponent.CanEndPhase || opponent.CanPass {
+		t.Errorf("opponent actions = %+v, want none", opponent)
+	}
+}
+
+// During an attack only the defender acts: blockers in the block step, counter cards
+// in the counter step
+func TestGetLegalActionsDuringAttack(t *testing.T) {
+	g := newTestGame()
+	g.GameState.Player1State.BattleArea["0"] = models.Friend{InstanceID: "atk", CardNo: "V-1000", Power: 1000, TurnPlayed: 1}
+	playerState := &g.GameState.Player2State
+	playerState.BattleArea["0"] = models.Friend{InstanceID: "blk", CardNo: "V-1000", Power: 1000, TurnPlayed: 2}
+	playerState.BattleArea["1"] = models.Friend{InstanceID: "rested", CardNo: "V-1000", Power: 1000, TurnPlayed: 2, IsRest: true}
+	playerState.EnergyArea = []models.EnergyCard{{InstanceID: "e1", Color: models.ColorRed}}
+	playerState.Hand = []models.CardInstance{{InstanceID: "counter", CardNo: "V-C1"}, {InstanceID: "main-counter", CardNo: "F-065"}, {InstanceID: "friend", CardNo: "V-1000"}}
+	h := NewEventHandler(g, loadTestCard)
+	
+	if err := h.DeclareAttack(1, "0", ""); err != nil {
+		t.Fatalf("DeclareAttack: %v", err)
+	}
+	attacker := h.GetLegalActions(1)
+	if attacker.CanPass || attacker.CanEndPhase || len(attacker.PlayableCards) != 0 {
+		t.Errorf("attacker actions = %+v, want none", attacker)
+	}
+	defender := h.GetLegalActions(2)
+	if !defender.CanPass || !reflect.DeepEqual(defender.Blockers, []string{"0"}) || len(defender.Counters) != 0 {
+		t.Errorf("block step: pass %v, blockers %v, counters %v; want pass and blocker 0", defender.CanPass, defender.Blockers, playableIDs(defender.Counters))
+	}
+	
+	if err := h.DeclareBlock(2, "0"); err != nil {
+		t.Fatalf("DeclareBlock: %v", err)
+	}
+	defender = h.GetLegalActions(2)
+	if got, want := playableIDs(defender.Counters), []string{"counter", "main-counter"}; !defender.CanPass || !reflect.DeepEqual(got, want) || len(defender.Blockers) != 0 {
+		t.Errorf("counter step: pass %v, counters %v, blockers %v; want pass and counters %v", defender.CanPass, got, defender.Blockers, want)
+	}
+}
+
+func TestGetLegalActionsWaitsForChoices(t *testing.T) {
+	g := newTestGame()
+	g.GameState.Player1State.Trash = []models.CardInstance{{InstanceID: "t1", CardNo: "V-1000"}, {InstanceID: "t2", CardNo: "V-1000"}, {InstanceID: "t3", CardNo: "V-1000"}}
+	g.GameState.Player1State.BattleArea["0"] = models.Friend{InstanceID: "ukki", CardNo: "F-042", Power: 2000, TurnPlayed: 1}
+	g.GameState.Player1State.EnergyArea = []models.EnergyCard{{InstanceID: "e1", Color: models.ColorRed}}
+	g.GameState.Player1State.Hand = []models.CardInstance{{InstanceID: "friend", CardNo: "V-1000"}}
+	h := NewEventHandler(g, loadTestCard)
+	if err := h.TriggerEvent(GameEvent{Type: EventFriendPlayed, Player: 1, CardNo: "F-042", InstanceID: "ukki", Phase: g.CurrentPhase}); err != nil {
+		t.Fatalf("TriggerEvent: %v", err)
+	}
+	
+	actions := h.GetLegalActions(1)
+	if actions.EffectChoice == nil || actions.EffectChoice.ID != g.GameState.PendingEffect.Choice.ID {
+		t.Fatalf("effect choice = %+v, want the pending one", actions.EffectChoice)
+	}
+	if len(actions.PlayableCards) != 0 || len(actions.Attacks) != 0 || actions.CanEndPhase {
+		t.Errorf("actions = %+v, want only the effect choice", actions)
+	}
+	if opponent := h.GetLegalActions(2); opponent.EffectChoice != nil {
+		t.Errorf("the opponent was offered player 1's choice")
+	}
+}
+
+func TestGetLegalActionsMulligan(t *testing.T) {
+	g := newTestGame()
+	g.Status = models.StatusMulligan
+	g.GameState.Player2State.MulliganDecided = true
+	h := NewEventHandler(g, loadTestCard)
+	
+	if !h.GetLegalActions(1).CanMulligan {
+		t.Errorf("player 1 cannot decide on a mulligan")
+	}
+	if h.GetLegalActions(2).CanMulligan {
+		t.Errorf("player 2 can decide on a mulligan twice")
+	}
+}
@@ -0,0 +1,482 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	
+	"mememe-tcg/internal/models"
+)
+
+var (
+	// ErrDeckEmpty is returned when a player has to take a card from an empty deck
+	ErrDeckEmpty = errors.New("deck is empty")
+	// ErrFriendNotFound is returned when a friend is not in the battle area
+	ErrFriendNotFound = errors.New("friend not found")
+	// ErrCardNotFound is returned when a card is not in the expected zone
+	ErrCardNotFound = errors.New("card not found")
+	// ErrBattleAreaFull is returned when a friend cannot enter a full battle area
+	ErrBattleAreaFull = errors.New("battle area is full")
+)
+
+// DrawCards moves cards from the top of a player's deck to their hand.
+// Emptying the deck loses the game, which is reported as ErrDeckEmpty.
+// Nothing is drawn once the game is finished.
+func (h *EventHandler) DrawCards(player int, count int) error {
+	playerState := h.context.GetPlayerState(player)
+	if playerState == nil {
+		return fmt.Errorf("no game state")
+	}
+	if h.game.Status == models.StatusFinished {
+		return &models.GameFinishedError{GameID: h.game.GameID, WinnerID: h.game.WinnerID}
+	}
+	
+	var events []GameEvent
+	drawn := 0
+	for ; drawn < count; drawn++ {
+		if len(playerState.Deck) == 0 {
+			break
+		}
+		transfer, err := h.transfer(ZoneMove{
+			Player:     player,
+			InstanceID: playerState.Deck[0].InstanceID,
+			From:       models.ZoneDeck,
+			To:         models.ZoneHand,
+		})
+		if err != nil {
+			return err
+		}
+		events = append(events, transfer.events...)
+	}
+	
+	if len(playerState.Deck) == 0 {
+		CheckVictory(h.game)
+		return fmt.Errorf("player %d: %w", player, ErrDeckEmpty)
+	}
+	
+	if drawn == 0 {
+		return nil
+	}
+	return h.emitAll(append(events, GameEvent{
+		Type:   EventCardsDrawn,
+		Player: player,
+		Phase:  h.game.CurrentPhase,
+		Data:   map[string]interface{}{"count": drawn},
+	}))
+}
+
+// DestroyFriend destroys one of a player's friends and sends it to the trash
+func (h *EventHandler) DestroyFriend(player int, instanceID string) error {
+	events, err := h.destroyFriend(player, instanceID)
+	if err != nil {
+		return err
+	}
+	return h.emitAll(events)
+}
+
+// ReturnToHand returns one of a player's friends from the battle area to their hand
+func (h *EventHandler) ReturnToHand(player int, instanceID string) error {
+	transfer, err := h.transfer(ZoneMove{
+		Player:     player,
+		InstanceID: instanceID,
+		From:       models.ZoneBattleArea,
+		To:         models.ZoneHand,
+	})
+	if err != nil {
+		return err
+	}
+	
+	return h.emitAll(append(transfer.events, GameEvent{
+		Type:       EventFriendReturned,
+		Player:     player,
+		CardNo:     transfer.card.CardNo,
+		InstanceID: transfer.card.InstanceID,
+		Target:     transfer.fromPos,
+		Phase:      h.game.CurrentPhase,
+	}))
+}
+
+// ModifyPower applies a power change to one of a player's friends. The change is
+// recorded as a modifier and does not touch the friend's base power.
+func (h *EventHandler) ModifyPower(player int, instanceID string, amount int, duration models.EffectDuration) error {
+	playerState := h.context.GetPlayerState(player)
+	if _, ok := findFriend(playerState, instanceID); !ok {
+		return fmt.Errorf("%s: %w", instanceID, ErrFriendNotFound)
+	}
+	
+	if duration == "" {
+		duration = models.DurationTurn
+	}
+	
+	h.game.GameState.PowerModifiers = append(h.game.GameState.PowerModifiers, models.PowerModifier{
+		TargetID: instanceID,
+		SourceID: h.context.SourceID,
+		Amount:   amount,
+		Duration: duration,
+		Turn:     h.game.CurrentTurn,
+	})
+	return nil
+}
+
+// RevealNegEnergy turns face-down cards in a player's negative energy area face
+// up, oldest first. Fewer cards are revealed if not enough are face down.
+func (h *EventHandler) RevealNegEnergy(player int, count int) error {
+	playerState := h.context.GetPlayerState(player)
+	if count > playerState.NegativeEnergy.FaceDownCount() {
+		count = playerState.NegativeEnergy.FaceDownCount()
+	}
+	if count == 0 {
+		return fmt.Errorf("no face-down negative energy to reveal")
+	}
+	
+	h.turnNegEnergy(playerState, count, true)
+	
+	return h.emit(GameEvent{
+		Type:   EventNegEnergyRevealed,
+		Player: player,
+		Phase:  h.game.CurrentPhase,
+		Data:   map[string]interface{}{"count": count},
+	})
+}
+
+// PlaceFieldCard puts a field card from a player's hand into play.
+// The previous field card goes to the trash.
+func (h *EventHandler) PlaceFieldCard(player int, instanceID string) error {
+	transfer, err := h.transfer(ZoneMove{
+		Player:     player,
+		InstanceID: instanceID,
+		From:       models.ZoneHand,
+		To:         models.ZoneField,
+	})
+	if err != nil {
+		return err
+	}
+	
+	return h.emitAll(append(transfer.events, GameEvent{
+		Type:       EventFieldPlayed,
+		Player:     player,
+		CardNo:     transfer.card.CardNo,
+		InstanceID: transfer.card.InstanceID,
+		Phase:      h.game.CurrentPhase,
+	}))
+}
+
+// MoveToTrash moves a card from the given zone to its owner's trash
+func (h *EventHandler) MoveToTrash(player int, instanceID string, from models.Zone) error {
+	transfer, err := h.transfer(ZoneMove{
+		Player:     player,
+		InstanceID: instanceID,
+		From:       from,
+		To:         models.ZoneTrash,
+	})
+	if err != nil {
+		return err
+	}
+	return h.emitAll(append(transfer.events, cardTrashedEvent(player, transfer.card, from, h.game.CurrentPhase)))
+}
+
+// DiscardFromDeckTop moves cards from the top of a player's deck to the trash
+func (h *EventHandler) DiscardFromDeckTop(player int, count int) error {
+	playerState := h.context.GetPlayerState(player)
+	if len(playerState.Deck) < count {
+		return fmt.Errorf("player %d: %w", player, ErrDeckEmpty)
+	}
+	
+	var events []GameEvent
+	for i := 0; i < count; i++ {
+		transfer, err := h.transfer(ZoneMove{
+			Player:     player,
+			InstanceID: playerState.Deck[0].InstanceID,
+			From:       models.ZoneDeck,
+			To:         models.ZoneTrash,
+		})
+		if err != nil {
+			return err
+		}
+		events = append(events, transfer.events...)
+		events = append(events, cardTrashedEvent(player, transfer.card, models.ZoneDeck, h.game.CurrentPhase))
+	}
+	return h.emitAll(events)
+}
+
+// MoveCardToDeck puts a card from a player's trash, hand or battle area on the
+// top or bottom of their deck
+func (h *EventHandler) MoveCardToDeck(player int, instanceID string, position models.DeckPosition) error {
+	if position != models.DeckTop && position != models.DeckBottom {
+		return fmt.Errorf("invalid deck position: %s", position)
+	}
+	
+	playerState := h.context.GetPlayerState(player)
+	
+	// A card already in the deck moves to the top or bottom
+	from := models.Zone("")
+	for _, zone := range []models.Zone{models.ZoneTrash, models.ZoneHand, models.ZoneBattleArea, models.ZoneDeck} {
+		if _, _, err := zoneCard(playerState, zone, instanceID); err == nil {
+			from = zone
+			break
+		}
+	}
+	if from == "" {
+		return fmt.Errorf("%s: %w", instanceID, ErrCardNotFound)
+	}
+	
+	transfer, err := h.transfer(ZoneMove{
+		Player:       player,
+		InstanceID:   instanceID,
+		From:         from,
+		To:           models.ZoneDeck,
+		DeckPosition: position,
+	})
+	if err != nil {
+		return err
+	}
+	
+	return h.emitAll(append(transfer.events, GameEvent{
+		Type:       EventCardToDeck,
+		Player:     player,
+		CardNo:     transfer.card.CardNo,
+		InstanceID: transfer.card.InstanceID,
+		Phase:      h.game.CurrentPhase,
+		Data:       map[string]interface{}{"from": from, "position": position},
+	}))
+}
+
+// AddToHand puts a card from a player's deck or trash into their hand
+func (h *EventHandler) AddToHand(player int, instanceID string, from models.Zone) error {
+	if from != models.ZoneDeck && from != models.ZoneTrash {
+		return fmt.Errorf("cannot add cards to hand from %s", from)
+	}
+	
+	transfer, err := h.transfer(ZoneMove{
+		Player:     player,
+		InstanceID: instanceID,
+		From:       from,
+		To:         models.ZoneHand,
+	})
+	if err != nil {
+		return err
+	}
+	
+	return h.emitAll(append(transfer.events, GameEvent{
+		Type:       EventCardToHand,
+		Player:     player,
+		CardNo:     transfer.card.CardNo,
+		InstanceID: transfer.card.InstanceID,
+		Phase:      h.game.CurrentPhase,
+		Data:       map[string]interface{}{"from": from},
+	}))
+}
+
+// DealDamage deals effect damage to a player: each point moves the top card
+// of their deck to their negative energy area
+func (h *EventHandler) DealDamage(player int, amount int) error {
+	return h.dealDamage(player, h.context.GetOpponentPlayer(player), models.CardInstance{}, amount)
+}
+
+// AddToEnergyArea puts a card from a player's hand, or the top of their deck,
+// into their energy area
+func (h *EventHandler) AddToEnergyArea(player int, instanceID string) error {
+	playerState := h.context.GetPlayerState(player)
+	
+	from := models.ZoneHand
+	if !hasCard(playerState.Hand, instanceID) {
+		from = models.ZoneDeck
+	}
+	return h.addToEnergyArea(player, instanceID, from)
+}
+
+// addToEnergyArea moves a card from the player's hand or deck top into their
+// energy area as active energy
+func (h *EventHandler) addToEnergyArea(player int, instanceID string, from models.Zone) error {
+	playerState := h.context.GetPlayerState(player)
+	
+	switch from {
+	case models.ZoneHand:
+	case models.ZoneDeck:
+		if len(playerState.Deck) == 0 || playerState.Deck[0].InstanceID != instanceID {
+			return fmt.Errorf("%s on top of deck: %w", instanceID, ErrCardNotFound)
+		}
+	default:
+		return fmt.Errorf("cannot add energy from %s", from)
+	}
+	
+	transfer, err := h.transfer(ZoneMove{
+		Player:     player,
+		InstanceID: instanceID,
+		From:       from,
+		To:         models.ZoneEnergyArea,
+	})
+	if err != nil {
+		return err
+	}
+	
+	return h.emitAll(append(transfer.events, GameEvent{
+		Type:       EventEnergyAdded,
+		Player:     player,
+		CardNo:     transfer.card.CardNo,
+		InstanceID: transfer.card.InstanceID,
+		Phase:      h.game.CurrentPhase,
+		Data:       map[string]interface{}{"from": from},
+	}))
+}
+
+// SummonFriend puts a friend from a player's trash or hand into the first free
+// battle area position without paying its cost. It counts as being played.
+func (h *EventHandler) SummonFriend(player int, instanceID string, from models.Zone, rested bool) error {
+	if from != models.ZoneTrash && from != models.ZoneHand {
+		return fmt.Errorf("cannot summon friends from %s", from)
+	}
+	
+	transfer, err := h.transfer(ZoneMove{
+		Player:     player,
+		InstanceID: instanceID,
+		From:       from,
+		To:         models.ZoneBattleArea,
+		Rested:     rested,
+	})
+	if err != nil {
+		return err
+	}
+	
+	return h.emitAll(append(transfer.events, GameEvent{
+		Type:       EventFriendPlayed,
+		Player:     player,
+		CardNo:     transfer.card.CardNo,
+		InstanceID: transfer.card.InstanceID,
+		Target:     transfer.toPos,
+		Phase:      h.game.CurrentPhase,
+		Data:       map[string]interface{}{"from": from},
+	}))
+}
+
+// dealDamage moves cards from the damaged player's deck top to their negative
+// energy area and fires the damage event for the source player
+func (h *EventHandler) dealDamage(damagedPlayer int, sourcePlayer int, source models.CardInstance, amount int) error {
+	damagedState := h.context.GetPlayerState(damagedPlayer)
+	
+	var events []GameEvent
+	dealt := 0
+	for ; dealt < amount && len(damagedState.Deck) > 0; dealt++ {
+		transfer, err := h.transfer(ZoneMove{
+			Player:     damagedPlayer,
+			InstanceID: damagedState.Deck[0].InstanceID,
+			From:       models.ZoneDeck,
+			To:         models.ZoneNegativeEnergy,
+		})
+		if err != nil {
+			return err
+		}
+		events = append(events, transfer.events...)
+	}
+	
+	// Reaching the negative energy limit ends the game before any triggers
+	if dealt == 0 || CheckVictory(h.game) {
+		return nil
+	}
+	
+	return h.emitAll(append(events, GameEvent{
+		Type:       EventDamageDealt,
+		Player:     sourcePlayer,
+		CardNo:     source.CardNo,
+		InstanceID: source.InstanceID,
+		Phase:      h.game.CurrentPhase,
+		Data: map[string]interface{}{
+			"damaged_player": damagedPlayer,
+			"amount":         dealt,
+		},
+	}))
+}
+
+// negEnergyCard returns a card as it is placed face down in the negative energy area
+func (h *EventHandler) negEnergyCard(instance models.CardInstance) models.NegativeEnergyCard {
+	card := models.NegativeEnergyCard{InstanceID: instance.InstanceID, CardNo: instance.CardNo}
+	if data, err := h.loadCard(instance.CardNo); err == nil {
+		card.Color = data.Color
+	}
+	return card
+}
+
+// turnNegEnergy turns count negative energy cards with the other orientation
+// face up or face down, oldest first
+func (h *EventHandler) turnNegEnergy(playerState *models.PlayerState, count int, faceUp bool) {
+	for i := range playerState.NegativeEnergy {
+		if count == 0 {
+			return
+		}
+		card := &playerState.NegativeEnergy[i]
+		if card.FaceUp == faceUp {
+			continue
+		}
+		card.FaceUp = faceUp
+		if faceUp {
+			card.RevealedTurn = h.game.CurrentTurn
+		}
+		count--
+	}
+}
+
+// emit triggers an event raised while an effect is resolving, keeping the
+// resolving effect's point of view intact for the rest of its Apply
+func (h *EventHandler) emit(event GameEvent) error {
+	activePlayer, sourceID := h.context.ActivePlayer, h.context.SourceID
+	defer func() {
+		h.context.ActivePlayer, h.context.SourceID = activePlayer, sourceID
+	}()
+	return h.TriggerEvent(event)
+}
+
+// findFriend returns the battle area position holding the given card instance
+func findFriend(playerState *models.PlayerState, instanceID string) (string, bool) {
+	for pos, friend := range playerState.BattleArea {
+		if friend.InstanceID == instanceID {
+			return pos, true
+		}
+	}
+	return "", false
+}
+
+// freePosition returns the lowest numbered battle area position that is empty
+func freePosition(playerState *models.PlayerState) (string, bool) {
+	for _, pos := range models.BattlePositions() {
+		if _, taken := playerState.BattleArea[pos]; !taken {
+			return pos, true
+		}
+	}
+	return "", false
+}
+
+// hasCard reports whether a zone holds a card instance
+func hasCard(cards []models.CardInstance, instanceID string) bool {
+	_, ok := findCard(cards, instanceID)
+	return ok
+}
+
+// findCard returns a card instance from a zone
+func findCard(cards []models.CardInstance, instanceID string) (models.CardInstance, bool) {
+	for _, card := range cards {
+		if card.InstanceID == instanceID {
+			return card, true
+		}
+	}
+	return models.CardInstance{}, false
+}
+
+// removeCard removes a card instance from a zone
+func removeCard(cards []models.CardInstance, instanceID string) ([]models.CardInstance, models.CardInstance, bool) {
+	for i, card := range cards {
+		if card.InstanceID == instanceID {
+			return append(cards[:i], cards[i+1:]...), card, true
+		}
+	}
+	return cards, models.CardInstance{}, false
+}
+
+func cardTrashedEvent(player int, card models.CardInstance, from models.Zone, phase models.GamePhase) GameEvent {
+	return GameEvent{
+		Type:       EventCardTrashed,
+		Player:     player,
+		CardNo:     card.CardNo,
+		InstanceID: card.InstanceID,
+		Phase:      phase,
+		Data:       map[string]interface{}{"from": from},
+	}
+}
@@ -0,0 +1,266 @@
+package game
+
+import (
+	
+	"mememe-tcg/internal/effects"
+	"mememe-tcg/internal/models"
+)
+
+// HandLimit is the most cards a player may keep in hand at the end of their turn
+const HandLimit = 7
+
+// StartTurn runs the automatic steps of the current turn from the start phase
+func (h *EventHandler) StartTurn() error {
+	return h.runPhases()
+}
+
+// AdvancePhase ends the current phase and runs the automatic steps of the
+// following phases. It stops at the main phase, or earlier when a card or
+// rule gives the active player a decision to make.
+func (h *EventHandler) AdvancePhase() error {
+	if err := h.ValidateEndPhase(h.game.ActivePlayer); err != nil {
+		return err
+	}
+	
+	if err := h.endPhase(); err != nil {
+		return err
+	}
+	return h.runPhases()
+}
+
+// ResolveEnergyChoice finishes an energy phase where the player could reveal
+// negative energy instead of placing the top card of their deck
+func (h *EventHandler) ResolveEnergyChoice(player int, useNegativeEnergy bool) error {
+	if err := h.requirePhaseChoice(player, models.PhaseChoiceEnergySource); err != nil {
+		return err
+	}
+	h.game.GameState.PendingPhaseChoice = nil
+	
+	if useNegativeEnergy {
+		if err := h.RevealNegEnergy(player, 1); err != nil {
+			return err
+		}
+	} else if err := h.placeEnergyFromDeck(player); err != nil {
+		return err
+	}
+	
+	return h.AdvancePhase()
+}
+
+// ResolveDiscardChoice discards the chosen cards to bring the hand down to the
+// hand limit, then passes the turn
+func (h *EventHandler) ResolveDiscardChoice(player int, instanceIDs []string) error {
+	if err := h.requirePhaseChoice(player, models.PhaseChoiceDiscard); err != nil {
+		return err
+	}
+	
+	choice := h.game.GameState.PendingPhaseChoice
+	if len(instanceIDs) != choice.Count {
+		return violation(models.RuleInvalidTarget, "must discard exactly %d cards", choice.Count)
+	}
+	
+	// Validate the whole selection before discarding anything
+	playerState := h.context.GetPlayerState(player)
+	hand := append([]models.CardInstance{}, playerState.Hand...)
+	for _, instanceID := range instanceIDs {
+		var ok bool
+		if hand, _, ok = removeCard(hand, instanceID); !ok {
+			return violation(models.RuleCardNotInHand, "%s is not in your hand", instanceID)
+		}
+	}
+	
+	h.game.GameState.PendingPhaseChoice = nil
+	for _, instanceID := range instanceIDs {
+		if err := h.MoveToTrash(player, instanceID, models.ZoneHand); err != nil {
+			return err
+		}
+	}
+	
+	return h.AdvancePhase()
+}
+
+// runPhases performs the current phase and moves on until the game needs input
+func (h *EventHandler) runPhases() error {
+	for h.game.Status == models.StatusPlaying && !h.EffectChoicePending() {
+		if err := h.TriggerEvent(GameEvent{
+			Type:   EventPhaseStart,
+			Player: h.game.ActivePlayer,
+			Phase:  h.game.CurrentPhase,
+		}); err != nil {
+			return err
+		}
+		if h.pausedAt(models.ResumePhaseStep) {
+			return nil
+		}
+		
+		if done, err := h.finishPhase(); err != nil || done {
+			return err
+		}
+	}
+	return nil
+}
+
+// finishPhase performs the automatic action of the current phase and ends it.
+// Returns true when the game stays in this phase waiting for a player.
+func (h *EventHandler) finishPhase() (bool, error) {
+	waiting, err := h.runPhaseStep()
+	if err != nil || waiting || h.game.CurrentPhase == models.PhaseMain {
+		return true, err
+	}
+	return false, h.endPhase()
+}
+
+// runPhaseStep performs the automatic action of the current phase.
+// Returns true when the phase is waiting for a player choice.
+func (h *EventHandler) runPhaseStep() (bool, error) {
+	player := h.game.ActivePlayer
+	playerState := h.context.GetPlayerState(player)
+	
+	switch h.game.CurrentPhase {
+	case models.PhaseStart:
+		// Rested cards become active again
+		for pos, friend := range playerState.BattleArea {
+			friend.IsRest = false
+			playerState.BattleArea[pos] = friend
+		}
+		for i := range playerState.EnergyArea {
+			playerState.EnergyArea[i].IsRest = false
+		}
+		
+	case models.PhaseDraw:
+		if err := h.DrawCards(player, 1); err != nil {
+			// Drawing the last card ends the game instead of failing the phase
+			if CheckVictory(h.game) {
+				return true, nil
+			}
+			return false, err
+		}
+		
+	case models.PhaseEnergy:
+		if h.hasEnergyPhaseAlternative(player) && playerState.NegativeEnergy.FaceDownCount() > 0 {
+			h.game.GameState.PendingPhaseChoice = &models.PhaseChoice{
+				Player: player,
+				Kind:   models.PhaseChoiceEnergySource,
+			}
+			return true, nil
+		}
+		if err := h.placeEnergyFromDeck(player); err != nil {
+			return false, err
+		}
+		
+	case models.PhaseEnd:
+		if len(playerState.Hand) > HandLimit {
+			h.game.GameState.PendingPhaseChoice = &models.PhaseChoice{
+				Player: player,
+				Kind:   models.PhaseChoiceDiscard,
+				Count:  len(playerState.Hand) - HandLimit,
+			}
+			return true, nil
+		}
+	}
+	
+	return false, nil
+}
+
+// endPhase fires the phase end event and moves to the next phase, passing the
+// turn after the end phase
+func (h *EventHandler) endPhase() error {
+	if err := h.TriggerEvent(GameEvent{
+		Type:   EventPhaseEnd,
+		Player: h.game.ActivePlayer,
+		Phase:  h.game.CurrentPhase,
+	}); err != nil {
+		return err
+	}
+	if h.pausedAt(models.ResumePhaseEnd) {
+		return nil
+	}
+	
+	return h.nextPhase()
+}
+
+// nextPhase moves to the phase after the current one, passing the turn after the end phase
+func (h *EventHandler) nextPhase() error {
+	switch h.game.CurrentPhase {
+	case models.PhaseStart:
+		h.game.CurrentPhase = models.PhaseDraw
+	case models.PhaseDraw:
+		h.game.CurrentPhase = models.PhaseEnergy
+	case models.PhaseEnergy:
+		h.game.CurrentPhase = models.PhaseMain
+	case models.PhaseMain:
+		h.game.CurrentPhase = models.PhaseEnd
+	case models.PhaseEnd:
+		if err := h.TriggerEvent(GameEvent{
+			Type:   EventTurnEnd,
+			Player: h.game.ActivePlayer,
+			Phase:  h.game.CurrentPhase,
+		}); err != nil {
+			return err
+		}
+		if h.pausedAt(models.ResumeTurnEnd) {
+			return nil
+		}
+		
+		return h.passTurn()
+	}
+	
+	return nil
+}
+
+// passTurn gives the turn to the other player, starting from their start phase
+func (h *EventHandler) passTurn() error {
+	h.game.CurrentPhase = models.PhaseStart
+	h.game.CurrentTurn++
+	h.game.ActivePlayer = h.context.GetOpponentPlayer(h.game.ActivePlayer)
+	
+	if err := h.TriggerEvent(GameEvent{
+		Type:   EventTurnStart,
+		Player: h.game.ActivePlayer,
+		Phase:  h.game.CurrentPhase,
+	}); err != nil {
+		return err
+	}
+	if h.pausedAt(models.ResumeTurnStart) {
+		return nil
+	}
+	
+	CheckVictory(h.game)
+	return nil
+}
+
+// placeEnergyFromDeck puts the top card of the player's deck into their energy area
+func (h *EventHandler) placeEnergyFromDeck(player int) error {
+	playerState := h.context.GetPlayerState(player)
+	if len(playerState.Deck) == 0 {
+		return nil
+	}
+	return h.addToEnergyArea(player, playerState.Deck[0].InstanceID, models.ZoneDeck)
+}
+
+// hasEnergyPhaseAlternative reports whether the player controls a card that
+// replaces the energy phase placement (F-090)
+func (h *EventHandler) hasEnergyPhaseAlternative(player int) bool {
+	for _, src := range h.getCardSources() {
+		if src.player != player {
+			continue
+		}
+		for _, effect := range h.getEffects(src.cardNo) {
+			if _, ok := effect.(*effects.EnergyPhaseAlternativeEffect); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (h *EventHandler) requirePhaseChoice(player int, kind models.PhaseChoiceKind) error {
+	choice := h.game.GameState.PendingPhaseChoice
+	if choice == nil || choice.Kind != kind {
+		return violation(models.RuleChoicePending, "no %s choice is pending", kind)
+	}
+	if choice.Player != player {
+		return violation(models.RuleNotYourTurn, "it is not your choice")
+	}
+	return h.validateNoEffectChoice()
+}
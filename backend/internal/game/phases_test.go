@@ -0,0 +1,146 @@
+package game
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	
+	"mememe-tcg/internal/models"
+)
+
+// Ending the main phase runs the end phase and the next player's start, draw
+// and energy phases without stopping
+func TestAdvancePhaseRunsTheNextTurn(t *testing.T) {
+	g := newTestGame()
+	g.GameState.Player1State.BattleArea["0"] = models.Friend{InstanceID: "mine", CardNo: "V-1000", Power: 1000, IsRest: true, TurnPlayed: 1}
+	g.GameState.Player2State.BattleArea["0"] = models.Friend{InstanceID: "theirs", CardNo: "V-1000", Power: 1000, IsRest: true, TurnPlayed: 2}
+	g.GameState.Player2State.EnergyArea = []models.EnergyCard{{InstanceID: "e1", CardNo: "V-1000", Color: models.ColorRed, IsRest: true}}
+	h := NewEventHandler(g, loadTestCard)
+	
+	if err := h.AdvancePhase(); err != nil {
+		t.Fatalf("AdvancePhase: %v", err)
+	}
+	
+	if g.CurrentTurn != 4 || g.ActivePlayer != 2 || g.CurrentPhase != models.PhaseMain {
+		t.Fatalf("turn %d, player %d, %s phase; want turn 4, player 2, main phase", g.CurrentTurn, g.ActivePlayer, g.CurrentPhase)
+	}
+	playerState := g.GameState.Player2State
+	if got := instanceIDs(playerState.Hand); !reflect.DeepEqual(got, []string{"p2-d1"}) {
+		t.Errorf("hand = %v, want the top card drawn", got)
+	}
+	if len(playerState.EnergyArea) != 2 || playerState.EnergyArea[1].InstanceID != "p2-d2" {
+		t.Errorf("energy area = %+v, want the next card placed", playerState.EnergyArea)
+	}
+	if playerState.BattleArea["0"].IsRest || playerState.EnergyArea[0].IsRest {
+		t.Errorf("the active player's cards are still rested")
+	}
+	if !g.GameState.Player1State.BattleArea["0"].IsRest {
+		t.Errorf("the other player's friend became active")
+	}
+}
+
+// The first player draws on the first turn too
+func TestStartTurnFirstTurnDraws(t *testing.T) {
+	g := newTestGame()
+	g.CurrentTurn = 1
+	g.CurrentPhase = models.PhaseStart
+	h := NewEventHandler(g, loadTestCard)
+	
+	if err := h.StartTurn(); err != nil {
+		t.Fatalf("StartTurn: %v", err)
+	}
+	if g.CurrentPhase != models.PhaseMain {
+		t.Errorf("phase = %s, want main", g.CurrentPhase)
+	}
+	playerState := g.GameState.Player1State
+	if len(playerState.Hand) != 1 || len(playerState.EnergyArea) != 1 {
+		t.Errorf("hand %d, energy %d; want 1 card drawn and 1 energy placed", len(playerState.Hand), len(playerState.EnergyArea))
+	}
+}
+
+// The end phase stops for the active player to discard down to the hand limit
+func TestEndPhaseDiscardChoice(t *testing.T) {
+	g := newTestGame()
+	playerState := &g.GameState.Player1State
+	for i := 1; i <= HandLimit+2; i++ {
+		playerState.Hand = append(playerState.Hand, models.CardInstance{InstanceID: fmt.Sprintf("h%d", i), CardNo: "V-1000"})
+	}
+	h := NewEventHandler(g, loadTestCard)
+	
+	if err := h.AdvancePhase(); err != nil {
+		t.Fatalf("AdvancePhase: %v", err)
+	}
+	choice := g.GameState.PendingPhaseChoice
+	if g.CurrentPhase != models.PhaseEnd || choice == nil || choice.Kind != models.PhaseChoiceDiscard || choice.Count != 2 {
+		t.Fatalf("%s phase with choice %+v, want the end phase waiting for 2 discards", g.CurrentPhase, choice)
+	}
+	if err := h.AdvancePhase(); ruleOf(err) != models.RuleChoicePending {
+		t.Errorf("ending the phase during the choice = %v, want rule %q", err, models.RuleChoicePending)
+	}
+	if err := h.ResolveDiscardChoice(1, []string{"h1"}); ruleOf(err) != models.RuleInvalidTarget {
+		t.Errorf("discarding too few = %v, want rule %q", err, models.RuleInvalidTarget)
+	}
+	
+	if err := h.ResolveDiscardChoice(1, []string{"h1", "h2"}); err != nil {
+		t.Fatalf("ResolveDiscardChoice: %v", err)
+	}
+	if len(playerState.Hand) != HandLimit || len(playerState.Trash) != 2 {
+		t.Errorf("hand %d, trash %d; want %d and 2", len(playerState.Hand), len(playerState.Trash), HandLimit)
+	}
+	if g.ActivePlayer != 2 || g.CurrentPhase != models.PhaseMain {
+		t.Errorf("player %d in the %s phase, want player 2 in the main phase", g.ActivePlayer, g.CurrentPhase)
+	}
+}
+
+// F-093 activates one of its controller's rested energy cards at the start of the
+// opponent's turn, asking which only when there is more than one
+func TestF093ActivatesEnergyAtOpponentsStart(t *testing.T) {
+	tests := []struct {
+		name   string
+		rested int
+		asks   bool
+		active []bool
+	}{
+		{name: "nothing rested", rested: 0, active: []bool{true, true, true}},
+		{name: "single target is picked", rested: 1, active: []bool{true, true, true}},
+		{name: "choice among targets", rested: 2, asks: true, active: []bool{true, false, true}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+			g.ActivePlayer = 2
+			playerState := &g.GameState.Player2State
+			playerState.FieldCard = &models.CardInstance{InstanceID: "pool", CardNo: "F-093"}
+			for i := 0; i < 3; i++ {
+				playerState.EnergyArea = append(playerState.EnergyArea, models.EnergyCard{InstanceID: fmt.Sprintf("e%d", i), CardNo: "V-1000", Color: models.ColorRed, IsRest: i < tt.rested})
+			}
+			h := NewEventHandler(g, loadTestCard)
+			
+			if err := h.AdvancePhase(); err != nil {
+				t.Fatalf("AdvancePhase: %v", err)
+			}
+			pending := g.GameState.PendingEffect
+			if asks := pending != nil; asks != tt.asks {
+				t.Fatalf("asked = %v, want %v", asks, tt.asks)
+			}
+			if pending != nil {
+				if pending.Choice.Player != 2 || len(pending.Choice.Options) != tt.rested {
+					t.Errorf("choice for player %d with %d options, want player 2 with %d", pending.Choice.Player, len(pending.Choice.Options), tt.rested)
+				}
+				if err := h.ResolveEffectChoice(2, pending.Choice.ID, []int{0}); err != nil {
+					t.Fatalf("ResolveEffectChoice: %v", err)
+				}
+			}
+			
+			for i, energy := range playerState.EnergyArea {
+				if !energy.IsRest != tt.active[i] {
+					t.Errorf("energy %d active = %v, want %v", i, !energy.IsRest, tt.active[i])
+				}
+			}
+			if g.ActivePlayer != 1 || g.CurrentPhase != models.PhaseMain {
+				t.Errorf("player %d in the %s phase, want player 1 in the main phase", g.ActivePlayer, g.CurrentPhase)
+			}
+		})
+	}
+}
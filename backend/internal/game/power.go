@@ -0,0 +1,168 @@
+package game
+
+import (
+	"mememe-tcg/internal/effects"
+	"mememe-tcg/internal/models"
+)
+
+// cardSource identifies a card in play that can provide a persistent effect
+type cardSource struct {
+	player     int
+	instanceID string
+	cardNo     string
+}
+
+// PowerLayers is a friend's power split into the layers it is computed from
+type PowerLayers struct {
+	Base       int `json:"base"`       // Printed power of the card
+	Applied    int `json:"applied"`    // Sum of modifiers from resolved effects that have not expired
+	Continuous int `json:"continuous"` // Sum of persistent effects of the cards in play
+	Total      int `json:"total"`      // Effective power, never below 0
+}
+
+// GetFriendPower returns a friend's effective power
+func (h *EventHandler) GetFriendPower(player int, pos string) int {
+	return h.GetPowerLayers(player, pos).Total
+}
+
+// GetPowerLayers computes a friend's power from its base power, the modifiers
+// applied to it and the persistent effects in play. Nothing is stored.
+func (h *EventHandler) GetPowerLayers(player int, pos string) PowerLayers {
+	playerState := h.context.GetPlayerState(player)
+	if playerState == nil {
+		return PowerLayers{}
+	}
+	
+	friend, exists := playerState.BattleArea[pos]
+	if !exists {
+		return PowerLayers{}
+	}
+	
+	layers := PowerLayers{Base: friend.Power}
+	
+	// Keep the point of view of any effect that is asking
+	activePlayer, sourceID := h.context.ActivePlayer, h.context.SourceID
+	
+	// Layer 1: modifiers applied by resolved effects
+	for _, modifier := range h.game.GameState.PowerModifiers {
+		if modifier.TargetID == friend.InstanceID && h.modifierActive(modifier) {
+			layers.Applied += modifier.Amount
+		}
+	}
+	
+	// Layer 2: persistent effects of the cards in play
+	friendCard, err := h.loadCard(friend.CardNo)
+	if err != nil {
+		friendCard = nil
+	}
+	
+	for _, src := range h.getCardSources() {
+		for _, effect := range h.getEffects(src.cardNo) {
+			modifier, ok := effect.(effects.PowerModifier)
+			if !ok {
+				continue
+			}
+			
+			card, err := h.loadCard(src.cardNo)
+			if err != nil {
+				continue
+			}
+			
+			h.context.ActivePlayer = src.player
+			h.context.SourceID = src.instanceID
+			layers.Continuous += modifier.GetPowerModifier(h.context, card, player, friend, friendCard)
+		}
+	}
+	h.context.ActivePlayer, h.context.SourceID = activePlayer, sourceID
+	
+	layers.Total = layers.Base + layers.Applied + layers.Continuous
+	if layers.Total < 0 {
+		layers.Total = 0
+	}
+	return layers
+}
+
+// dropPowerModifiers removes the modifiers on a friend
+func (h *EventHandler) dropPowerModifiers(instanceID string) {
+	state := h.game.GameState
+	
+	modifiers := state.PowerModifiers[:0]
+	for _, modifier := range state.PowerModifiers {
+		if modifier.TargetID != instanceID {
+			modifiers = append(modifiers, modifier)
+		}
+	}
+	state.PowerModifiers = modifiers
+}
+
+// RefreshPower drops modifiers whose friend has left the battle area or whose
+// "while" condition has ended, and recomputes the effective power of every friend
+func (h *EventHandler) RefreshPower() {
+	state := h.game.GameState
+	if state == nil {
+		return
+	}
+	
+	inPlay := make(map[string]bool)
+	for _, player := range []int{1, 2} {
+		for _, friend := range h.context.GetPlayerState(player).BattleArea {
+			inPlay[friend.InstanceID] = true
+		}
+	}
+	
+	modifiers := state.PowerModifiers[:0]
+	for _, modifier := range state.PowerModifiers {
+		if inPlay[modifier.TargetID] && h.modifierActive(modifier) {
+			modifiers = append(modifiers, modifier)
+		}
+	}
+	state.PowerModifiers = modifiers
+	
+	for _, player := range []int{1, 2} {
+		playerState := h.context.GetPlayerState(player)
+		for _, pos := range sortedPositions(playerState.BattleArea) {
+			friend := playerState.BattleArea[pos]
+			friend.EffectivePower = h.GetFriendPower(player, pos)
+			playerState.BattleArea[pos] = friend
+		}
+	}
+}
+
+// getCardSources returns every card instance in the battle areas and field zones.
+// Each copy of a card is its own source.
+func (h *EventHandler) getCardSources() []cardSource {
+	var sources []cardSource
+	
+	for _, player := range []int{1, 2} {
+		playerState := h.context.GetPlayerState(player)
+		if playerState == nil {
+			continue
+		}
+		
+		for _, pos := range sortedPositions(playerState.BattleArea) {
+			friend := playerState.BattleArea[pos]
+			sources = append(sources, cardSource{player: player, instanceID: friend.InstanceID, cardNo: friend.CardNo})
+		}
+		if playerState.FieldCard != nil {
+			field := playerState.FieldCard
+			sources = append(sources, cardSource{player: player, instanceID: field.InstanceID, cardNo: field.CardNo})
+		}
+	}
+	
+	return sources
+}
+
+// getEffects returns the effects of all of a card's abilities
+func (h *EventHandler) getEffects(cardNo string) []effects.Effect {
+	abilities := h.effectRegistry.GetAbilities(cardNo)
+	result := make([]effects.Effect, 0, len(abilities))
+	for _, ability := range abilities {
+		result = append(result, ability.Effect)
+	}
+	return result
+}
+
+// sortedPositions returns the battle area positions in a stable order
+func sortedPositions(battleArea map[string]models.Friend) []string {
+	return effects.SortedPositions(battleArea)
+}
@@ -0,0 +1,70 @@
+package game
+
+import (
+	"testing"
+	
+	"mememe-tcg/internal/effects"
+	"mememe-tcg/internal/models"
+)
+
+// Power is the printed power plus the applied modifiers plus the persistent effects
+// in play. "V-HAND" gets +1000 for every 2 cards in its controller's hand.
+func TestGetPowerLayers(t *testing.T) {
+	tests := []struct {
+		name     string
+		cardNo   string
+		hand     int
+		modifier int
+		layers   PowerLayers
+	}{
+		{name: "printed power", cardNo: "V-1000", layers: PowerLayers{Base: 1000, Total: 1000}},
+		{name: "applied modifier", cardNo: "V-1000", modifier: 2000, layers: PowerLayers{Base: 1000, Applied: 2000, Total: 3000}},
+		{name: "persistent effect", cardNo: "V-HAND", hand: 5, layers: PowerLayers{Base: 1000, Continuous: 2000, Total: 3000}},
+		{name: "both layers", cardNo: "V-HAND", hand: 4, modifier: -1000, layers: PowerLayers{Base: 1000, Applied: -1000, Continuous: 2000, Total: 2000}},
+		{name: "never below 0", cardNo: "V-1000", modifier: -3000, layers: PowerLayers{Base: 1000, Applied: -3000, Total: 0}},
+	}
+	
+	registry := effects.NewEffectRegistry()
+	effects.InitializeEffects(registry)
+	registry.Register("V-HAND", &effects.HandSizePowerBoostEffect{
+		BaseEffect:    effects.BaseEffect{Trigger: effects.TriggerPersistent},
+		CardsPerBoost: 2,
+		PowerBoost:    1000,
+	})
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+			playerState := &g.GameState.Player1State
+			playerState.Hand = playerState.Deck[:tt.hand]
+			playerState.BattleArea["0"] = models.Friend{InstanceID: "friend", CardNo: tt.cardNo, Power: 1000, TurnPlayed: 1}
+			// Only the friend with the effect gets its boost
+			playerState.BattleArea["1"] = models.Friend{InstanceID: "other", CardNo: "V-1000", Power: 1000, TurnPlayed: 1}
+			h := NewEventHandler(g, loadTestCard)
+			h.effectRegistry = registry
+			if tt.modifier != 0 {
+				if err := h.ModifyPower(1, "friend", tt.modifier, models.DurationPermanent); err != nil {
+					t.Fatalf("ModifyPower: %v", err)
+				}
+			}
+			
+			h.context.ActivePlayer, h.context.SourceID = 2, "asking"
+			if got := h.GetPowerLayers(1, "0"); got != tt.layers {
+				t.Errorf("layers = %+v, want %+v", got, tt.layers)
+			}
+			if got := h.GetFriendPower(1, "1"); got != 1000 {
+				t.Errorf("other friend's power = %d, want 1000", got)
+			}
+			if h.context.ActivePlayer != 2 || h.context.SourceID != "asking" {
+				t.Errorf("context changed to player %d, source %q", h.context.ActivePlayer, h.context.SourceID)
+			}
+		})
+	}
+}
+
+func TestGetPowerLayersWithoutFriend(t *testing.T) {
+	h := NewEventHandler(newTestGame(), loadTestCard)
+	if got := h.GetPowerLayers(1, "0"); got != (PowerLayers{}) {
+		t.Errorf("layers of an empty position = %+v, want none", got)
+	}
+}
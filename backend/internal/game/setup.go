@@ -0,0 +1,95 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"mememe-tcg/internal/models"
+)
+
+// OpeningHandSize is the number of cards each player draws before the first turn
+const OpeningHandSize = 5
+
+// ExpandDeck turns a deck list into one card instance per physical card.
+// Instance IDs are prefixed with the owning player, e.g. "p1-07".
+func ExpandDeck(player int, deck *models.Deck) []models.CardInstance {
+	cards := make([]models.CardInstance, 0, 50)
+	for _, dc := range deck.Cards {
+		for i := 0; i < dc.Quantity; i++ {
+			cards = append(cards, models.CardInstance{
+				InstanceID: fmt.Sprintf("p%d-%02d", player, len(cards)+1),
+				CardNo:     dc.CardNo,
+			})
+		}
+	}
+	return cards
+}
+
+// NewPlayerState creates the starting zones for a player with the given deck.
+// Every other zone starts empty: no energy or negative energy is placed before
+// the first turn. The deck is also kept in its unshuffled order so the game can be replayed.
+func NewPlayerState(deck []models.CardInstance) models.PlayerState {
+	return models.PlayerState{
+		StartingDeck:   append([]models.CardInstance{}, deck...),
+		Deck:           deck,
+		Hand:           []models.CardInstance{},
+		BattleArea:     make(map[string]models.Friend),
+		EnergyArea:     []models.EnergyCard{},
+		NegativeEnergy: models.NegativeEnergyZone{},
+		Trash:          []models.CardInstance{},
+	}
+}
+
+// ShuffleCards shuffles cards in place. Every shuffle in a game draws from its own
+// RNG derived from the game seed and the shuffle count, so the whole game can be
+// replayed from the stored seed and starting decks.
+func ShuffleCards(gameModel *models.Game, cards []models.CardInstance) {
+	state := gameModel.GameState
+	rng := rand.New(rand.NewSource(shuffleSeed(gameModel.Seed, state.ShuffleCount)))
+	state.ShuffleCount++
+	
+	rng.Shuffle(len(cards), func(i, j int) {
+		cards[i], cards[j] = cards[j], cards[i]
+	})
+}
+
+// DealOpeningHands shuffles both decks and draws the opening hands
+func DealOpeningHands(gameModel *models.Game) {
+	for _, playerState := range []*models.PlayerState{
+		&gameModel.GameState.Player1State,
+		&gameModel.GameState.Player2State,
+	} {
+		ShuffleCards(gameModel, playerState.Deck)
+		drawOpeningHand(playerState)
+	}
+}
+
+// RedrawOpeningHand returns the hand to the deck, shuffles and draws a new opening hand
+func RedrawOpeningHand(gameModel *models.Game, playerState *models.PlayerState) {
+	playerState.Deck = append(playerState.Deck, playerState.Hand...)
+	playerState.Hand = []models.CardInstance{}
+	ShuffleCards(gameModel, playerState.Deck)
+	drawOpeningHand(playerState)
+}
+
+// shuffleSeed mixes the game seed with the shuffle count, so games with
+// adjacent seeds do not share shuffles
+func shuffleSeed(seed int64, count int) int64 {
+	return int64(mix64(mix64(uint64(seed)) + uint64(count)))
+}
+
+// mix64 is the splitmix64 finalizer
+func mix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+func drawOpeningHand(playerState *models.PlayerState) {
+	count := OpeningHandSize
+	if count > len(playerState.Deck) {
+		count = len(playerState.Deck)
+	}
+	playerState.Hand = append(playerState.Hand, playerState.Deck[:count]...)
+	playerState.Deck = playerState.Deck[count:]
+}
@@ -2,8 +2,8 @@ package game
 
 import (
 	"fmt"
-	"github.com/your-org/card_game/backend/internal/effects"
-	"github.com/your-org/card_game/backend/internal/models"
+	"mememe-tcg/internal/effects"
+	"mememe-tcg/internal/models"
 )
 
 // TargetValidator validates and filters targets for effects
@@ -18,23 +18,28 @@ func NewTargetValidator(context *effects.GameContext) *TargetValidator {
 	}
 }
 
+// ValidTargets returns the targets of an effect that pass its target spec
+func (v *TargetValidator) ValidTargets(effect effects.Effect, source *models.Card) []effects.Target {
+	return effect.GetTargetSpec().Filter(v.context, effect.GetTargets(v.context, source))
+}
+
 // ValidateTargets checks if the selected targets are valid for an effect
 func (v *TargetValidator) ValidateTargets(effect effects.Effect, source *models.Card, selectedTargets []effects.Target) error {
 	// Get all valid targets
-	validTargets := effect.GetTargets(v.context, source)
+	validTargets := v.ValidTargets(effect, source)
 	
-	// Create a map for quick lookup
-	validMap := make(map[string]bool)
-	for _, target := range validTargets {
-		key := fmt.Sprintf("%s_%s_%s", target.Type, target.ID, target.Location)
-		validMap[key] = true
+	spec := effect.GetTargetSpec()
+	if spec.Max == effects.AllTargets && len(selectedTargets) > 0 {
+		return violation(models.RuleInvalidTarget, "the effect applies to every valid target")
+	}
+	if spec.Max >= 0 && len(selectedTargets) > spec.Max {
+		return violation(models.RuleInvalidTarget, "select at most %d targets", spec.Max)
 	}
 	
 	// Check each selected target
 	for _, selected := range selectedTargets {
-		key := fmt.Sprintf("%s_%s_%s", selected.Type, selected.ID, selected.Location)
-		if !validMap[key] {
-			return fmt.Errorf("invalid target: %s", key)
+		if len(matchTargets(validTargets, []effects.Target{selected})) == 0 {
+			return violation(models.RuleInvalidTarget, "invalid target: %s %s in %s of player %d", selected.Type, selected.ID, selected.Zone, selected.Player)
 		}
 	}
 	
@@ -42,7 +47,7 @@ func (v *TargetValidator) ValidateTargets(effect effects.Effect, source *models.
 }
 
 // FilterTargetsByType filters targets by their type
-func (v *TargetValidator) FilterTargetsByType(targets []effects.Target, targetType string) []effects.Target {
+func (v *TargetValidator) FilterTargetsByType(targets []effects.Target, targetType models.TargetType) []effects.Target {
 	filtered := make([]effects.Target, 0)
 	for _, target := range targets {
 		if target.Type == targetType {
@@ -52,11 +57,11 @@ func (v *TargetValidator) FilterTargetsByType(targets []effects.Target, targetTy
 	return filtered
 }
 
-// FilterTargetsByLocation filters targets by their location
-func (v *TargetValidator) FilterTargetsByLocation(targets []effects.Target, location string) []effects.Target {
+// FilterTargetsByZone filters targets by the zone they are in
+func (v *TargetValidator) FilterTargetsByZone(targets []effects.Target, zone models.Zone) []effects.Target {
 	filtered := make([]effects.Target, 0)
 	for _, target := range targets {
-		if target.Location == location {
+		if target.Zone == zone {
 			filtered = append(filtered, target)
 		}
 	}
@@ -65,82 +70,14 @@ func (v *TargetValidator) FilterTargetsByLocation(targets []effects.Target, loca
 
 // FilterFriendsByPower filters friend targets by power threshold
 func (v *TargetValidator) FilterFriendsByPower(targets []effects.Target, maxPower int) []effects.Target {
-	filtered := make([]effects.Target, 0)
-	for _, target := range targets {
-		if target.Type == "friend" {
-			if friend, ok := target.Data.(models.Friend); ok {
-				if friend.Power <= maxPower {
-					filtered = append(filtered, target)
-				}
-			}
-		}
-	}
-	return filtered
+	spec := effects.TargetSpec{Types: []models.TargetType{models.TargetFriend}, MaxPower: maxPower}
+	return spec.Filter(v.context, targets)
 }
 
 // FilterFriendsByCost filters friend targets by cost threshold
 func (v *TargetValidator) FilterFriendsByCost(targets []effects.Target, maxCost int) []effects.Target {
-	filtered := make([]effects.Target, 0)
-	for _, target := range targets {
-		if target.Type == "friend" {
-			// TODO: Load card data to check cost
-			filtered = append(filtered, target)
-		}
-	}
-	return filtered
-}
-
-// GetTargetRequirements analyzes an effect to determine targeting requirements
-func (v *TargetValidator) GetTargetRequirements(effect effects.Effect) TargetRequirements {
-	// Analyze the effect type to determine requirements
-	// This is a simplified version - in practice, effects should provide this info
-	
-	switch effect.(type) {
-	case *effects.DestroyFriendEffect:
-		return TargetRequirements{
-			MinTargets:  1,
-			MaxTargets:  1,
-			TargetTypes: []string{"friend"},
-			Mandatory:   true,
-			Description: "相手のふれんど1体を選択",
-		}
-		
-	case *effects.ReturnToHandEffect:
-		return TargetRequirements{
-			MinTargets:  1,
-			MaxTargets:  1,
-			TargetTypes: []string{"friend"},
-			Mandatory:   true,
-			Description: "ふれんど1体を選択",
-		}
-		
-	case *effects.PowerModifierEffect:
-		return TargetRequirements{
-			MinTargets:  0,
-			MaxTargets:  -1, // -1 means all valid targets
-			TargetTypes: []string{"friend"},
-			Mandatory:   false,
-			Description: "対象のふれんど",
-		}
-		
-	default:
-		return TargetRequirements{
-			MinTargets:  0,
-			MaxTargets:  0,
-			TargetTypes: []string{},
-			Mandatory:   false,
-			Description: "",
-		}
-	}
-}
-
-// TargetRequirements describes the targeting requirements for an effect
-type TargetRequirements struct {
-	MinTargets  int      // Minimum number of targets required
-	MaxTargets  int      // Maximum number of targets allowed (-1 for unlimited)
-	TargetTypes []string // Valid target types
-	Mandatory   bool     // Whether targeting is mandatory
-	Description string   // Human-readable description
+	spec := effects.TargetSpec{Types: []models.TargetType{models.TargetFriend}, MaxCost: maxCost}
+	return spec.Filter(v.context, targets)
 }
 
 // TargetSelector provides methods for interactive target selection
@@ -157,13 +94,17 @@ func NewTargetSelector(validator *TargetValidator) *TargetSelector {
 
 // GetSelectableTargets returns targets that can be selected for an effect
 func (s *TargetSelector) GetSelectableTargets(effect effects.Effect, source *models.Card) SelectableTargets {
-	targets := effect.GetTargets(s.validator.context, source)
-	requirements := s.validator.GetTargetRequirements(effect)
+	requirements := effect.GetTargetSpec()
+	// Effects that pick no targets have nothing to select
+	targets := []effects.Target{}
+	if requirements.Max != 0 {
+		targets = s.validator.ValidTargets(effect, source)
+	}
 	
-	// Group targets by type and location for easier UI display
+	// Group targets by type and zone for easier UI display
 	grouped := make(map[string][]effects.Target)
 	for _, target := range targets {
-		key := fmt.Sprintf("%s_%s", target.Type, getTargetLocationGroup(target.Location))
+		key := fmt.Sprintf("%s_%s", target.Type, target.Zone)
 		grouped[key] = append(grouped[key], target)
 	}
 	
@@ -178,14 +119,43 @@ func (s *TargetSelector) GetSelectableTargets(effect effects.Effect, source *mod
 type SelectableTargets struct {
 	Targets      []effects.Target
 	Grouped      map[string][]effects.Target
-	Requirements TargetRequirements
+	Requirements effects.TargetSpec
+}
+
+// pickTargets returns the valid targets with the given instance IDs, in the order
+// of the IDs. It also reports whether every ID was a valid target picked only once.
+func pickTargets(validTargets []effects.Target, ids []string) ([]effects.Target, bool) {
+	picked := make([]effects.Target, 0, len(ids))
+	seen := make(map[string]bool)
+	ok := true
+	for _, id := range ids {
+		found := false
+		for _, target := range validTargets {
+			if target.ID == id && !seen[id] {
+				picked = append(picked, target)
+				seen[id] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			ok = false
+		}
+	}
+	return picked, ok
 }
 
-// Helper function to group locations
-func getTargetLocationGroup(location string) string {
-	// Extract the general location type
-	if len(location) > 12 && location[:12] == "battle_area_" {
-		return "battle_area"
+// matchTargets returns the valid targets that refer to the same cards as the
+// given targets, in the order of the given targets
+func matchTargets(validTargets []effects.Target, targets []effects.Target) []effects.Target {
+	matched := make([]effects.Target, 0, len(targets))
+	for _, target := range targets {
+		for _, valid := range validTargets {
+			if valid.Is(target) {
+				matched = append(matched, valid)
+				break
+			}
+		}
 	}
-	return location
-}
\ No newline at end of file
+	return matched
+}
@@ -0,0 +1,252 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	
+	"mememe-tcg/internal/effects"
+	"mememe-tcg/internal/models"
+)
+
+// specEffect targets whatever its spec collects
+type specEffect struct {
+	effects.BaseEffect
+	spec effects.TargetSpec
+}
+
+func (e *specEffect) CanActivate(game *effects.GameContext, source *models.Card) bool {
+	return true
+}
+
+func (e *specEffect) GetTargets(game *effects.GameContext, source *models.Card) []effects.Target {
+	return e.spec.Collect(game)
+}
+
+func (e *specEffect) GetTargetSpec() effects.TargetSpec {
+	return e.spec
+}
+
+func (e *specEffect) Apply(game *effects.GameContext, source *models.Card, targets []effects.Target) error {
+	return nil
+}
+
+// newTargetGame returns a game where player 1 has a friend and an energy card and
+// player 2 has two friends and a support card in the trash
+func newTargetGame() *models.Game {
+	g := newTestGame()
+	g.GameState.Player1State.BattleArea["2"] = models.Friend{InstanceID: "mine", CardNo: "V-1000", Power: 1000, TurnPlayed: 1}
+	g.GameState.Player1State.EnergyArea = []models.EnergyCard{{InstanceID: "e1", CardNo: "V-1000", Color: models.ColorRed}}
+	g.GameState.Player2State.BattleArea["5"] = models.Friend{InstanceID: "strong", CardNo: "V-3000", Power: 3000, TurnPlayed: 2}
+	g.GameState.Player2State.BattleArea["0"] = models.Friend{InstanceID: "weak", CardNo: "V-1000", Power: 1000, TurnPlayed: 2}
+	g.GameState.Player2State.Trash = []models.CardInstance{{InstanceID: "t1", CardNo: "V-S2"}}
+	return g
+}
+
+// Targets name the player, zone and slot of the card they refer to. Collect lists
+// the controller's cards first and battle areas in position order.
+func TestTargetSpecCollect(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     effects.TargetSpec
+		modifier int // Power added to the opponent's weaker friend
+		targets  []string
+	}{
+		{
+			name:    "every friend",
+			spec:    effects.TargetSpec{Types: []models.TargetType{models.TargetFriend}, Zones: []models.Zone{models.ZoneBattleArea}},
+			targets: []string{"1 battle_area 2 mine", "2 battle_area 0 weak", "2 battle_area 5 strong"},
+		},
+		{
+			name:    "opponent's friends with 2000 power or less",
+			spec:    effects.TargetSpec{Zones: []models.Zone{models.ZoneBattleArea}, Owner: effects.OwnerOpponent, MaxPower: 2000},
+			targets: []string{"2 battle_area 0 weak"},
+		},
+		{
+			name:     "power includes modifiers",
+			spec:     effects.TargetSpec{Zones: []models.Zone{models.ZoneBattleArea}, Owner: effects.OwnerOpponent, MaxPower: 2000},
+			modifier: 2000,
+			targets:  []string{},
+		},
+		{
+			name:    "support cards in any trash",
+			spec:    effects.TargetSpec{Zones: []models.Zone{models.ZoneTrash}, CardTypes: []models.CardType{models.CardTypeSupport}},
+			targets: []string{"2 trash  t1"},
+		},
+		{
+			name:    "own energy",
+			spec:    effects.TargetSpec{Zones: []models.Zone{models.ZoneEnergyArea}, Owner: effects.OwnerSelf},
+			targets: []string{"1 energy_area 0 e1"},
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTargetGame()
+			h := NewEventHandler(g, loadTestCard)
+			if tt.modifier != 0 {
+				if err := h.ModifyPower(2, "weak", tt.modifier, models.DurationPermanent); err != nil {
+					t.Fatalf("ModifyPower: %v", err)
+				}
+			}
+			
+			h.context.ActivePlayer = 1
+			got := []string{}
+			for _, target := range tt.spec.Filter(h.context, tt.spec.Collect(h.context)) {
+				got = append(got, fmt.Sprintf("%d %s %s %s", target.Player, target.Zone, target.Slot, target.ID))
+			}
+			if !reflect.DeepEqual(got, tt.targets) {
+				t.Errorf("targets = %q, want %q", got, tt.targets)
+			}
+		})
+	}
+}
+
+func TestValidateTargets(t *testing.T) {
+	effect := &specEffect{spec: effects.TargetSpec{
+		Min:   1,
+		Max:   1,
+		Types: []models.TargetType{models.TargetFriend},
+		Zones: []models.Zone{models.ZoneBattleArea},
+		Owner: effects.OwnerOpponent,
+	}}
+	weak := models.Target{Type: models.TargetFriend, Player: 2, Zone: models.ZoneBattleArea, ID: "weak"}
+	
+	tests := []struct {
+		name     string
+		selected []models.Target
+		valid    bool
+	}{
+		{name: "opponent's friend", selected: []models.Target{weak}, valid: true},
+		{name: "own friend", selected: []models.Target{{Type: models.TargetFriend, Player: 1, Zone: models.ZoneBattleArea, ID: "mine"}}},
+		{name: "wrong owner", selected: []models.Target{{Type: models.TargetFriend, Player: 1, Zone: models.ZoneBattleArea, ID: "weak"}}},
+		{name: "wrong zone", selected: []models.Target{{Type: models.TargetCard, Player: 2, Zone: models.ZoneTrash, ID: "t1"}}},
+		{name: "too many", selected: []models.Target{weak, {Type: models.TargetFriend, Player: 2, Zone: models.ZoneBattleArea, ID: "strong"}}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewEventHandler(newTargetGame(), loadTestCard)
+			h.context.ActivePlayer = 1
+			
+			err := NewTargetValidator(h.context).ValidateTargets(effect, testCards["V-1000"], tt.selected)
+			if tt.valid && err != nil {
+				t.Errorf("ValidateTargets: %v", err)
+			}
+			if !tt.valid && ruleOf(err) != models.RuleInvalidTarget {
+				t.Errorf("ValidateTargets = %v, want rule %q", err, models.RuleInvalidTarget)
+			}
+		})
+	}
+}
+
+// Targets go to the client as JSON and come back unchanged in stored stacks
+func TestTargetJSON(t *testing.T) {
+	target := effects.FriendTarget(2, "5", models.Friend{InstanceID: "strong", CardNo: "V-3000"})
+	
+	data, err := json.Marshal(target)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	want := `{"type":"friend","player":2,"zone":"battle_area","slot":"5","id":"strong","card_no":"V-3000"}`
+	if string(data) != want {
+		t.Errorf("JSON = %s, want %s", data, want)
+	}
+	
+	var decoded models.Target
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if decoded != target || !decoded.Is(target) {
+		t.Errorf("decoded %+v, want %+v", decoded, target)
+	}
+}
+
+// F-025 picks a support card of cost 3 or less from its controller's trash and
+// puts it into their hand. A single valid target is picked without asking.
+func TestTargetSpecFiltersTrashSupportCards(t *testing.T) {
+	tests := []struct {
+		name      string
+		trash     []models.CardInstance
+		options   []string // Instance IDs offered in the choice, nil when nothing is asked
+		selection []int
+		hand      []string
+		wantTrash []string
+	}{
+		{
+			name:      "nothing matches",
+			trash:     []models.CardInstance{{InstanceID: "friend", CardNo: "V-1000"}, {InstanceID: "costly", CardNo: "V-S4"}},
+			hand:      []string{},
+			wantTrash: []string{"friend", "costly"},
+		},
+		{
+			name:      "single target is picked",
+			trash:     []models.CardInstance{{InstanceID: "friend", CardNo: "V-1000"}, {InstanceID: "cheap", CardNo: "V-S2"}, {InstanceID: "costly", CardNo: "V-S4"}},
+			hand:      []string{"cheap"},
+			wantTrash: []string{"friend", "costly"},
+		},
+		{
+			name:      "choice among targets",
+			trash:     []models.CardInstance{{InstanceID: "cheap1", CardNo: "V-S2"}, {InstanceID: "costly", CardNo: "V-S4"}, {InstanceID: "cheap2", CardNo: "V-S2"}},
+			options:   []string{"cheap1", "cheap2"},
+			selection: []int{1},
+			hand:      []string{"cheap2"},
+			wantTrash: []string{"cheap1", "costly"},
+		},
+		{
+			name:      "declined",
+			trash:     []models.CardInstance{{InstanceID: "cheap1", CardNo: "V-S2"}, {InstanceID: "cheap2", CardNo: "V-S2"}},
+			options:   []string{"cheap1", "cheap2"},
+			selection: []int{},
+			hand:      []string{},
+			wantTrash: []string{"cheap1", "cheap2"},
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+			g.GameState.Player1State.Trash = tt.trash
+			// The opponent's trash is never a target
+			g.GameState.Player2State.Trash = []models.CardInstance{{InstanceID: "theirs", CardNo: "V-S2"}}
+			g.GameState.Player1State.BattleArea["0"] = models.Friend{InstanceID: "shimon", CardNo: "F-025", Power: 2000, TurnPlayed: 3}
+			h := NewEventHandler(g, loadTestCard)
+			
+			if err := h.TriggerEvent(GameEvent{Type: EventFriendPlayed, Player: 1, CardNo: "F-025", InstanceID: "shimon", Phase: g.CurrentPhase}); err != nil {
+				t.Fatalf("TriggerEvent: %v", err)
+			}
+			
+			pending := g.GameState.PendingEffect
+			if tt.options == nil {
+				if pending != nil {
+					t.Fatalf("unexpected choice: %+v", pending.Choice)
+				}
+			} else {
+				if pending == nil {
+					t.Fatalf("no choice is pending")
+				}
+				var options []string
+				for _, option := range pending.Choice.Options {
+					options = append(options, option.ID)
+				}
+				if !reflect.DeepEqual(options, tt.options) {
+					t.Errorf("options = %v, want %v", options, tt.options)
+				}
+				if err := h.ResolveEffectChoice(1, pending.Choice.ID, tt.selection); err != nil {
+					t.Fatalf("ResolveEffectChoice: %v", err)
+				}
+			}
+			
+			if got := instanceIDs(g.GameState.Player1State.Hand); !reflect.DeepEqual(got, tt.hand) {
+				t.Errorf("hand = %v, want %v", got, tt.hand)
+			}
+			if got := instanceIDs(g.GameState.Player1State.Trash); !reflect.DeepEqual(got, tt.wantTrash) {
+				t.Errorf("trash = %v, want %v", got, tt.wantTrash)
+			}
+			if got := instanceIDs(g.GameState.Player2State.Trash); !reflect.DeepEqual(got, []string{"theirs"}) {
+				t.Errorf("opponent's trash = %v", got)
+			}
+		})
+	}
+}
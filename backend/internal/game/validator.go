@@ -0,0 +1,248 @@
+package game
+
+import (
+	"fmt"
+	
+	"mememe-tcg/internal/effects"
+	"mememe-tcg/internal/models"
+)
+
+// ValidatePlayCard checks that a player may play a card from their hand now,
+// and for a friend that it can enter the given battle area position.
+// The defending player may play counter cards in an attack's counter window.
+func (h *EventHandler) ValidatePlayCard(player int, instanceID string, position string) error {
+	if attack := h.game.GameState.PendingAttack; attack != nil && player != attack.AttackerPlayer {
+		return h.ValidatePlayCounter(player, instanceID)
+	}
+	
+	if err := h.validateMainPhaseAction(player); err != nil {
+		return err
+	}
+	
+	card, err := h.loadHandCard(player, instanceID)
+	if err != nil {
+		return err
+	}
+	
+	// Counter-only support cards cannot be played in the main phase
+	if card.Type == models.CardTypeSupport && card.IsCounter && !card.IsMainCounter {
+		return violation(models.RuleCounterOnly, "%s can only be played as a counter", card.CardNo)
+	}
+	
+	if card.Type == models.CardTypeFriend {
+		return h.ValidatePosition(player, position)
+	}
+	
+	return nil
+}
+
+// ValidatePosition checks the battle area position a friend played from hand
+// enters. An empty position is the first free one. When the battle area is
+// full, the position must hold the friend the new one replaces.
+func (h *EventHandler) ValidatePosition(player int, position string) error {
+	full := h.BattleAreaFull(player)
+	if position == "" {
+		if full {
+			return violation(models.RuleBattleAreaFull, "battle area is full: choose a friend to replace")
+		}
+		return nil
+	}
+	
+	if !models.IsBattlePosition(position) {
+		return violation(models.RuleInvalidPosition, "%s is not a battle area position", position)
+	}
+	if _, taken := h.context.GetPlayerState(player).BattleArea[position]; taken && !full {
+		return violation(models.RuleInvalidPosition, "position %s is taken", position)
+	}
+	return nil
+}
+
+// ValidatePlayCounter checks that the defending player may play a card from
+// their hand in the counter window of the pending attack
+func (h *EventHandler) ValidatePlayCounter(player int, instanceID string) error {
+	if _, err := h.getDefenderWindow(player, models.AttackStepCounter); err != nil {
+		return err
+	}
+	
+	card, err := h.loadHandCard(player, instanceID)
+	if err != nil {
+		return err
+	}
+	
+	if card.Type != models.CardTypeSupport || !(card.IsCounter || card.IsMainCounter) {
+		return violation(models.RuleNotCounter, "%s is not a counter card", card.CardNo)
+	}
+	
+	return nil
+}
+
+// ValidateTargets checks the targets picked for a support card played from hand
+// against what its ability can target right now
+func (h *EventHandler) ValidateTargets(player int, instanceID string, targets []string) error {
+	if len(targets) == 0 {
+		return nil
+	}
+	
+	card, err := h.loadHandCard(player, instanceID)
+	if err != nil {
+		return err
+	}
+	if card.Type != models.CardTypeSupport {
+		return violation(models.RuleInvalidTarget, "only support cards take targets when played")
+	}
+	
+	activePlayer, sourceID := h.context.ActivePlayer, h.context.SourceID
+	defer func() {
+		h.context.ActivePlayer, h.context.SourceID = activePlayer, sourceID
+	}()
+	h.context.ActivePlayer = player
+	h.context.SourceID = instanceID
+	validator := NewTargetValidator(h.context)
+	
+	for _, ability := range h.effectRegistry.GetAbilities(card.CardNo) {
+		if ability.Trigger != h.supportTrigger() {
+			continue
+		}
+		
+		// Effects with their own choices pick targets while they resolve, and
+		// effects on every valid target take none
+		spec := ability.Effect.GetTargetSpec()
+		if _, ok := ability.Effect.(effects.ChoiceEffect); ok || spec.Max <= 0 {
+			return violation(models.RuleInvalidTarget, "%s does not take targets when played", card.CardNo)
+		}
+		if len(targets) > spec.Max {
+			return violation(models.RuleInvalidTarget, "%s takes at most %d targets", card.CardNo, spec.Max)
+		}
+		if _, ok := pickTargets(validator.ValidTargets(ability.Effect, card), targets); !ok {
+			return violation(models.RuleInvalidTarget, "%v are not valid targets for %s", targets, card.CardNo)
+		}
+	}
+	
+	return nil
+}
+
+// loadHandCard returns the card data of a card in the player's hand
+func (h *EventHandler) loadHandCard(player int, instanceID string) (*models.Card, error) {
+	instance, ok := findCard(h.context.GetPlayerState(player).Hand, instanceID)
+	if !ok {
+		return nil, violation(models.RuleCardNotInHand, "%s is not in your hand", instanceID)
+	}
+	return h.loadCard(instance.CardNo)
+}
+
+// ValidateAttack checks that a player's friend may attack the given target.
+// An empty targetPos is a direct attack on the opponent.
+func (h *EventHandler) ValidateAttack(player int, attackerPos string, targetPos string) error {
+	if err := h.validateMainPhaseAction(player); err != nil {
+		return err
+	}
+	
+	playerState := h.context.GetPlayerState(player)
+	attacker, exists := playerState.BattleArea[attackerPos]
+	if !exists {
+		return violation(models.RuleNoFriend, "no friend at position %s", attackerPos)
+	}
+	if attacker.IsRest {
+		return violation(models.RuleFriendRested, "friend at position %s is rested", attackerPos)
+	}
+	if attacker.TurnPlayed == h.game.CurrentTurn && !h.HasCapability(player, attacker, effects.CapabilityAttackImmediately) {
+		return violation(models.RuleSummoningSickness, "friend at position %s cannot attack on the turn it was played", attackerPos)
+	}
+	
+	if targetPos != "" {
+		oppState := h.context.GetPlayerState(h.context.GetOpponentPlayer(player))
+		if _, exists := oppState.BattleArea[targetPos]; !exists {
+			return violation(models.RuleNoFriend, "no opponent friend at position %s", targetPos)
+		}
+	}
+	
+	return nil
+}
+
+// ValidateBlock checks that a player's friend may block the pending attack
+func (h *EventHandler) ValidateBlock(player int, blockerPos string) error {
+	if _, err := h.getDefenderWindow(player, models.AttackStepBlock); err != nil {
+		return err
+	}
+	
+	playerState := h.context.GetPlayerState(player)
+	blocker, exists := playerState.BattleArea[blockerPos]
+	if !exists {
+		return violation(models.RuleNoFriend, "no friend at position %s", blockerPos)
+	}
+	if blocker.IsRest && !h.HasCapability(player, blocker, effects.CapabilityBlockWhileRested) {
+		return violation(models.RuleFriendRested, "friend at position %s is rested", blockerPos)
+	}
+	
+	return nil
+}
+
+// ValidateEndPhase checks that a player may end the current phase
+func (h *EventHandler) ValidateEndPhase(player int) error {
+	if player != h.game.ActivePlayer {
+		return violation(models.RuleNotYourTurn, "it is not your turn")
+	}
+	if h.game.GameState.PendingAttack != nil {
+		return violation(models.RuleAttackInProgress, "an attack is in progress")
+	}
+	if choice := h.game.GameState.PendingPhaseChoice; choice != nil {
+		return violation(models.RuleChoicePending, "waiting for a %s choice", choice.Kind)
+	}
+	return h.validateNoEffectChoice()
+}
+
+// HasCapability reports whether any card in play grants the friend a rule exception
+func (h *EventHandler) HasCapability(player int, friend models.Friend, capability effects.Capability) bool {
+	// Keep the point of view of any effect that is asking
+	activePlayer, sourceID := h.context.ActivePlayer, h.context.SourceID
+	defer func() {
+		h.context.ActivePlayer, h.context.SourceID = activePlayer, sourceID
+	}()
+	
+	for _, src := range h.getCardSources() {
+		for _, effect := range h.getEffects(src.cardNo) {
+			granter, ok := effect.(effects.CapabilityGranter)
+			if !ok {
+				continue
+			}
+			
+			card, err := h.loadCard(src.cardNo)
+			if err != nil {
+				continue
+			}
+			
+			h.context.ActivePlayer = src.player
+			h.context.SourceID = src.instanceID
+			if granter.GrantsCapability(h.context, card, player, friend, capability) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateMainPhaseAction checks the shared rules for the active player's main phase actions
+func (h *EventHandler) validateMainPhaseAction(player int) error {
+	if player != h.game.ActivePlayer {
+		return violation(models.RuleNotYourTurn, "it is not your turn")
+	}
+	if h.game.GameState.PendingAttack != nil {
+		return violation(models.RuleAttackInProgress, "an attack is in progress")
+	}
+	if h.game.CurrentPhase != models.PhaseMain {
+		return violation(models.RuleWrongPhase, "this action is only allowed in the main phase (current: %s)", h.game.CurrentPhase)
+	}
+	return h.validateNoEffectChoice()
+}
+
+// validateNoEffectChoice rejects actions while an effect waits for a player's choice
+func (h *EventHandler) validateNoEffectChoice() error {
+	if pending := h.game.GameState.PendingEffect; pending != nil {
+		return violation(models.RuleChoicePending, "waiting for player %d to answer %s", pending.Choice.Player, pending.Choice.ID)
+	}
+	return nil
+}
+
+func violation(rule models.Rule, format string, args ...interface{}) *models.RuleViolation {
+	return &models.RuleViolation{Rule: rule, Message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,153 @@
+package game
+
+import (
+	"testing"
+	
+	"mememe-tcg/internal/effects"
+	"mememe-tcg/internal/models"
+)
+
+// Every rejected action reports the rule it breaks
+func TestRuleViolations(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  func(g *models.Game)
+		action func(h *EventHandler) error
+		rule   models.Rule
+	}{
+		{
+			name:   "play on the opponent's turn",
+			action: func(h *EventHandler) error { return h.ValidatePlayCard(2, "p2-hand", "") },
+			rule:   models.RuleNotYourTurn,
+		},
+		{
+			name:   "play outside the main phase",
+			setup:  func(g *models.Game) { g.CurrentPhase = models.PhaseEnd },
+			action: func(h *EventHandler) error { return h.ValidatePlayCard(1, "hand", "") },
+			rule:   models.RuleWrongPhase,
+		},
+		{
+			name:   "play a card not in hand",
+			action: func(h *EventHandler) error { return h.ValidatePlayCard(1, "p1-d1", "") },
+			rule:   models.RuleCardNotInHand,
+		},
+		{
+			name:   "play a counter-only card in the main phase",
+			action: func(h *EventHandler) error { return h.ValidatePlayCard(1, "counter", "") },
+			rule:   models.RuleCounterOnly,
+		},
+		{
+			name:   "play a friend outside the battle area",
+			action: func(h *EventHandler) error { return h.ValidatePlayCard(1, "hand", "10") },
+			rule:   models.RuleInvalidPosition,
+		},
+		{
+			name: "play a friend into a full battle area",
+			setup: func(g *models.Game) {
+				for _, pos := range models.BattlePositions() {
+					g.GameState.Player1State.BattleArea[pos] = models.Friend{InstanceID: "f" + pos, CardNo: "V-1000", Power: 1000, TurnPlayed: 1}
+				}
+			},
+			action: func(h *EventHandler) error { return h.ValidatePlayCard(1, "hand", "") },
+			rule:   models.RuleBattleAreaFull,
+		},
+		{
+			name:   "attack from an empty position",
+			action: func(h *EventHandler) error { return h.DeclareAttack(1, "5", "") },
+			rule:   models.RuleNoFriend,
+		},
+		{
+			name:   "attack with a rested friend",
+			setup:  func(g *models.Game) { g.GameState.Player1State.BattleArea["1"] = models.Friend{InstanceID: "rested", CardNo: "V-1000", Power: 1000, IsRest: true, TurnPlayed: 1} },
+			action: func(h *EventHandler) error { return h.DeclareAttack(1, "1", "") },
+			rule:   models.RuleFriendRested,
+		},
+		{
+			name:   "attack on the turn a friend was played",
+			setup:  func(g *models.Game) { g.GameState.Player1State.BattleArea["1"] = models.Friend{InstanceID: "new", CardNo: "V-1000", Power: 1000, TurnPlayed: 3} },
+			action: func(h *EventHandler) error { return h.DeclareAttack(1, "1", "") },
+			rule:   models.RuleSummoningSickness,
+		},
+		{
+			name:   "F-015 attacks on the turn it was played",
+			setup:  func(g *models.Game) { g.GameState.Player1State.BattleArea["1"] = models.Friend{InstanceID: "tyranno", CardNo: "F-015", Power: 4000, TurnPlayed: 3} },
+			action: func(h *EventHandler) error { return h.DeclareAttack(1, "1", "") },
+		},
+		{
+			name: "attack during an attack",
+			action: func(h *EventHandler) error {
+				if err := h.DeclareAttack(1, "0", ""); err != nil {
+					return err
+				}
+				return h.DeclareAttack(1, "0", "")
+			},
+			rule: models.RuleAttackInProgress,
+		},
+		{
+			name:   "block without an attack",
+			action: func(h *EventHandler) error { return h.DeclareBlock(2, "0") },
+			rule:   models.RuleNoAttack,
+		},
+		{
+			name: "attacker blocks",
+			action: func(h *EventHandler) error {
+				if err := h.DeclareAttack(1, "0", ""); err != nil {
+					return err
+				}
+				return h.DeclareBlock(1, "0")
+			},
+			rule: models.RuleNotDefender,
+		},
+		{
+			name: "end the phase during an attack",
+			action: func(h *EventHandler) error {
+				if err := h.DeclareAttack(1, "0", ""); err != nil {
+					return err
+				}
+				return h.ValidateEndPhase(1)
+			},
+			rule: models.RuleAttackInProgress,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+			g.GameState.Player1State.Hand = []models.CardInstance{{InstanceID: "hand", CardNo: "V-1000"}, {InstanceID: "counter", CardNo: "V-C1"}}
+			g.GameState.Player2State.Hand = []models.CardInstance{{InstanceID: "p2-hand", CardNo: "V-1000"}}
+			g.GameState.Player1State.BattleArea["0"] = models.Friend{InstanceID: "atk", CardNo: "V-1000", Power: 1000, TurnPlayed: 1}
+			g.GameState.Player2State.BattleArea["0"] = models.Friend{InstanceID: "blk", CardNo: "V-1000", Power: 1000, TurnPlayed: 2}
+			if tt.setup != nil {
+				tt.setup(g)
+			}
+			h := NewEventHandler(g, loadTestCard)
+			
+			err := tt.action(h)
+			if tt.rule == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if got := ruleOf(err); got != tt.rule {
+				t.Errorf("error = %v (rule %q), want rule %q", err, got, tt.rule)
+			}
+		})
+	}
+}
+
+// Checking a capability keeps the point of view of the effect that asked
+func TestHasCapabilityKeepsContext(t *testing.T) {
+	g := newTestGame()
+	tyranno := models.Friend{InstanceID: "tyranno", CardNo: "F-015", Power: 4000, TurnPlayed: 3}
+	g.GameState.Player1State.BattleArea["0"] = tyranno
+	h := NewEventHandler(g, loadTestCard)
+	
+	h.context.ActivePlayer, h.context.SourceID = 2, "asking"
+	if !h.HasCapability(1, tyranno, effects.CapabilityAttackImmediately) {
+		t.Errorf("F-015 cannot attack on the turn it was played")
+	}
+	if h.context.ActivePlayer != 2 || h.context.SourceID != "asking" {
+		t.Errorf("context changed to player %d, source %q", h.context.ActivePlayer, h.context.SourceID)
+	}
+}
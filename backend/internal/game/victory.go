@@ -0,0 +1,48 @@
+package game
+
+import (
+	"time"
+	
+	"mememe-tcg/internal/models"
+)
+
+// NegativeEnergyLimit is the number of negative energy cards that loses the game
+const NegativeEnergyLimit = 7
+
+// CheckVictory evaluates the loss conditions for both players and finishes
+// the game when one is met. Returns true if the game is finished.
+func CheckVictory(gameModel *models.Game) bool {
+	if gameModel.Status == models.StatusFinished {
+		return true
+	}
+	if gameModel.GameState == nil {
+		return false
+	}
+	
+	player1Lost := hasLost(&gameModel.GameState.Player1State)
+	player2Lost := hasLost(&gameModel.GameState.Player2State)
+	if !player1Lost && !player2Lost {
+		return false
+	}
+	
+	// Both players losing at once is a draw, which leaves WinnerID empty
+	gameModel.WinnerID = nil
+	if !player1Lost {
+		winner := gameModel.Player1ID
+		gameModel.WinnerID = &winner
+	} else if !player2Lost {
+		winner := gameModel.Player2ID
+		gameModel.WinnerID = &winner
+	}
+	
+	now := time.Now()
+	gameModel.Status = models.StatusFinished
+	gameModel.FinishedAt = &now
+	gameModel.GameState.PendingAttack = nil
+	return true
+}
+
+// hasLost reports whether a player has 7 negative energy or no cards left in the deck
+func hasLost(playerState *models.PlayerState) bool {
+	return len(playerState.NegativeEnergy) >= NegativeEnergyLimit || len(playerState.Deck) == 0
+}
@@ -0,0 +1,138 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	
+	"mememe-tcg/internal/models"
+)
+
+func negativeEnergy(count int) models.NegativeEnergyZone {
+	zone := models.NegativeEnergyZone{}
+	for i := 0; i < count; i++ {
+		zone = append(zone, models.NegativeEnergyCard{InstanceID: fmt.Sprintf("n%d", i), CardNo: "V-1000"})
+	}
+	return zone
+}
+
+func TestCheckVictory(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(g *models.Game)
+		finished bool
+		winner   uint // 0 for a draw
+	}{
+		{name: "no loss condition", setup: func(g *models.Game) { g.GameState.Player2State.NegativeEnergy = negativeEnergy(6) }},
+		{name: "7 negative energy", setup: func(g *models.Game) { g.GameState.Player2State.NegativeEnergy = negativeEnergy(7) }, finished: true, winner: 1},
+		{name: "empty deck", setup: func(g *models.Game) { g.GameState.Player1State.Deck = []models.CardInstance{} }, finished: true, winner: 2},
+		{
+			name: "both lose at once",
+			setup: func(g *models.Game) {
+				g.GameState.Player1State.NegativeEnergy = negativeEnergy(7)
+				g.GameState.Player2State.Deck = []models.CardInstance{}
+			},
+			finished: true,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+			tt.setup(g)
+			
+			if got := CheckVictory(g); got != tt.finished {
+				t.Fatalf("CheckVictory = %v, want %v", got, tt.finished)
+			}
+			if !tt.finished {
+				if g.Status != models.StatusPlaying {
+					t.Errorf("status = %s, want %s", g.Status, models.StatusPlaying)
+				}
+				return
+			}
+			if g.Status != models.StatusFinished || g.FinishedAt == nil {
+				t.Errorf("status = %s, finished at %v; want a finished game", g.Status, g.FinishedAt)
+			}
+			var winner uint
+			if g.WinnerID != nil {
+				winner = *g.WinnerID
+			}
+			if winner != tt.winner {
+				t.Errorf("winner = %d, want %d", winner, tt.winner)
+			}
+		})
+	}
+}
+
+// The seventh damage of a direct attack ends the game
+func TestDirectAttackWinsOnSeventhNegativeEnergy(t *testing.T) {
+	g := newTestGame()
+	g.GameState.Player1State.BattleArea["0"] = models.Friend{InstanceID: "atk", CardNo: "V-1000", Power: 1000, TurnPlayed: 1}
+	g.GameState.Player2State.NegativeEnergy = negativeEnergy(6)
+	h := NewEventHandler(g, loadTestCard)
+	
+	if err := h.DeclareAttack(1, "0", ""); err != nil {
+		t.Fatalf("DeclareAttack: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := h.PassAttackStep(2); err != nil {
+			t.Fatalf("PassAttackStep: %v", err)
+		}
+	}
+	
+	if g.Status != models.StatusFinished || g.WinnerID == nil || *g.WinnerID != 1 {
+		t.Errorf("status %s, winner %v; want player 1 to win", g.Status, g.WinnerID)
+	}
+}
+
+func TestDrawCards(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  func(g *models.Game)
+		count     int
+		hand      int
+		deckEmpty bool // ErrDeckEmpty is returned
+		over      bool // The game was already over, so a GameFinishedError is returned
+		winner    uint
+	}{
+		{name: "draw", count: 2, hand: 2},
+		{name: "draw the last card", count: 5, hand: 5, deckEmpty: true, winner: 2},
+		{name: "draw past the last card", count: 7, hand: 5, deckEmpty: true, winner: 2},
+		{
+			name:   "game already over",
+			setup:  func(g *models.Game) { g.GameState.Player1State.NegativeEnergy = negativeEnergy(7); CheckVictory(g) },
+			count:  1,
+			over:   true,
+			winner: 2,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+			if tt.setup != nil {
+				tt.setup(g)
+			}
+			h := NewEventHandler(g, loadTestCard)
+			
+			err := h.DrawCards(1, tt.count)
+			var finished *models.GameFinishedError
+			if got := errors.Is(err, ErrDeckEmpty); got != tt.deckEmpty {
+				t.Errorf("DrawCards = %v, deck empty = %v, want %v", err, got, tt.deckEmpty)
+			}
+			if got := errors.As(err, &finished); got != tt.over {
+				t.Errorf("DrawCards = %v, game finished = %v, want %v", err, got, tt.over)
+			}
+			if err != nil && !tt.deckEmpty && !tt.over {
+				t.Fatalf("DrawCards: %v", err)
+			}
+			
+			if got := len(g.GameState.Player1State.Hand); got != tt.hand {
+				t.Errorf("hand size = %d, want %d", got, tt.hand)
+			}
+			if tt.winner != 0 && (g.WinnerID == nil || *g.WinnerID != tt.winner) {
+				t.Errorf("winner = %v, want %d", g.WinnerID, tt.winner)
+			}
+		})
+	}
+}
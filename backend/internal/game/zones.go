@@ -0,0 +1,285 @@
+package game
+
+import (
+	"fmt"
+	
+	"mememe-tcg/internal/models"
+)
+
+// ZoneMove moves one card from a zone of a player's side to another zone of the same side
+type ZoneMove struct {
+	Player       int
+	InstanceID   string
+	From         models.Zone
+	To           models.Zone
+	Position     string              // Battle area position to enter, the first free one if empty
+	Replace      bool                // Put the friend at Position in the trash to make room
+	DeckPosition models.DeckPosition // End of the deck to enter, the bottom if empty
+	Rested       bool                // Enter the battle area rested
+}
+
+// zoneTransfer is a card that has been moved and the events the move raises
+type zoneTransfer struct {
+	card    models.CardInstance
+	fromPos string // Battle area position the card left
+	toPos   string // Battle area position the card entered
+	events  []GameEvent
+}
+
+// MoveCard moves a card between two zones of a player's side. Nothing changes
+// unless the card is in the source zone and the destination can take it.
+// The move raises a leave event for the source zone and an enter event for the destination.
+func (h *EventHandler) MoveCard(move ZoneMove) error {
+	transfer, err := h.transfer(move)
+	if err != nil {
+		return err
+	}
+	return h.emitAll(transfer.events)
+}
+
+// transfer performs a zone move and returns its events without raising them
+func (h *EventHandler) transfer(move ZoneMove) (zoneTransfer, error) {
+	playerState := h.context.GetPlayerState(move.Player)
+	if playerState == nil {
+		return zoneTransfer{}, fmt.Errorf("no game state")
+	}
+	
+	// Only the deck can be reordered by moving a card within it
+	if move.From == move.To && move.From != models.ZoneDeck {
+		return zoneTransfer{}, fmt.Errorf("%s is already in %s", move.InstanceID, move.From)
+	}
+	
+	card, fromPos, err := zoneCard(playerState, move.From, move.InstanceID)
+	if err != nil {
+		return zoneTransfer{}, err
+	}
+	
+	// Check the destination before changing anything
+	var data *models.Card
+	toPos := ""
+	switch move.To {
+	case models.ZoneDeck:
+		if move.DeckPosition != "" && move.DeckPosition != models.DeckTop && move.DeckPosition != models.DeckBottom {
+			return zoneTransfer{}, fmt.Errorf("invalid deck position: %s", move.DeckPosition)
+		}
+	case models.ZoneHand, models.ZoneTrash, models.ZoneNegativeEnergy:
+	case models.ZoneBattleArea:
+		if data, err = h.loadCard(card.CardNo); err != nil {
+			return zoneTransfer{}, err
+		}
+		if data.Type != models.CardTypeFriend {
+			return zoneTransfer{}, fmt.Errorf("%s is not a friend card", card.CardNo)
+		}
+		toPos = move.Position
+		if toPos == "" {
+			var ok bool
+			if toPos, ok = freePosition(playerState); !ok {
+				return zoneTransfer{}, fmt.Errorf("player %d: %w", move.Player, ErrBattleAreaFull)
+			}
+		} else if !models.IsBattlePosition(toPos) {
+			return zoneTransfer{}, fmt.Errorf("invalid battle area position: %s", toPos)
+		} else if _, taken := playerState.BattleArea[toPos]; taken && !move.Replace {
+			return zoneTransfer{}, fmt.Errorf("battle area position %s is taken", toPos)
+		}
+	case models.ZoneEnergyArea:
+		if data, err = h.loadCard(card.CardNo); err != nil {
+			return zoneTransfer{}, err
+		}
+	case models.ZoneField:
+		if data, err = h.loadCard(card.CardNo); err != nil {
+			return zoneTransfer{}, err
+		}
+		if data.Type != models.CardTypeField {
+			return zoneTransfer{}, fmt.Errorf("%s is not a field card", card.CardNo)
+		}
+	default:
+		return zoneTransfer{}, fmt.Errorf("unknown zone: %s", move.To)
+	}
+	
+	removeFromZone(playerState, move.From, card.InstanceID, fromPos)
+	
+	// A friend that leaves the battle area is a new card: effects on it end
+	if move.From == models.ZoneBattleArea {
+		h.dropPowerModifiers(card.InstanceID)
+	}
+	
+	result := zoneTransfer{card: card, fromPos: fromPos, toPos: toPos}
+	
+	// The friend being replaced goes to the trash before the new one enters
+	if replaced, taken := playerState.BattleArea[toPos]; move.To == models.ZoneBattleArea && taken {
+		trashed, err := h.transfer(ZoneMove{
+			Player:     move.Player,
+			InstanceID: replaced.InstanceID,
+			From:       models.ZoneBattleArea,
+			To:         models.ZoneTrash,
+		})
+		if err != nil {
+			return zoneTransfer{}, err
+		}
+		result.events = append(result.events, trashed.events...)
+	}
+	
+	result.events = append(result.events, h.zoneEvent(EventCardLeftZone, move, card, fromPos))
+	
+	switch move.To {
+	case models.ZoneDeck:
+		if move.DeckPosition == models.DeckTop {
+			playerState.Deck = append([]models.CardInstance{card}, playerState.Deck...)
+		} else {
+			playerState.Deck = append(playerState.Deck, card)
+		}
+	case models.ZoneHand:
+		playerState.Hand = append(playerState.Hand, card)
+	case models.ZoneTrash:
+		playerState.Trash = append(playerState.Trash, card)
+	case models.ZoneNegativeEnergy:
+		// Cards are placed in the negative energy area face down
+		playerState.NegativeEnergy = append(playerState.NegativeEnergy, h.negEnergyCard(card))
+	case models.ZoneBattleArea:
+		playerState.BattleArea[toPos] = models.Friend{
+			InstanceID: card.InstanceID,
+			CardNo:     card.CardNo,
+			Power:      data.Power,
+			IsRest:     move.Rested,
+			TurnPlayed: h.game.CurrentTurn,
+		}
+	case models.ZoneEnergyArea:
+		playerState.EnergyArea = append(playerState.EnergyArea, models.EnergyCard{
+			InstanceID: card.InstanceID,
+			CardNo:     card.CardNo,
+			Color:      data.Color,
+		})
+	case models.ZoneField:
+		// The previous field card goes to the trash
+		if previous := playerState.FieldCard; previous != nil {
+			replaced, err := h.transfer(ZoneMove{
+				Player:     move.Player,
+				InstanceID: previous.InstanceID,
+				From:       models.ZoneField,
+				To:         models.ZoneTrash,
+			})
+			if err != nil {
+				return zoneTransfer{}, err
+			}
+			result.events = append(result.events, replaced.events...)
+		}
+		playerState.FieldCard = &card
+	}
+	
+	result.events = append(result.events, h.zoneEvent(EventCardEnteredZone, move, card, toPos))
+	return result, nil
+}
+
+// BattleAreaFull reports whether a player has a friend in every battle area position
+func (h *EventHandler) BattleAreaFull(player int) bool {
+	_, free := freePosition(h.context.GetPlayerState(player))
+	return !free
+}
+
+// entryPositions returns the battle area positions a friend played now can
+// enter. When the area is full these are the occupied positions, and true is
+// returned because entering one replaces the friend there.
+func (h *EventHandler) entryPositions(player int) ([]string, bool) {
+	battleArea := h.context.GetPlayerState(player).BattleArea
+	full := h.BattleAreaFull(player)
+	
+	positions := []string{}
+	for _, pos := range models.BattlePositions() {
+		if _, taken := battleArea[pos]; taken == full {
+			positions = append(positions, pos)
+		}
+	}
+	return positions, full
+}
+
+// zoneCard returns a card in one of a player's zones and, in the battle area, its position
+func zoneCard(playerState *models.PlayerState, zone models.Zone, instanceID string) (models.CardInstance, string, error) {
+	switch zone {
+	case models.ZoneDeck:
+		if card, ok := findCard(playerState.Deck, instanceID); ok {
+			return card, "", nil
+		}
+	case models.ZoneHand:
+		if card, ok := findCard(playerState.Hand, instanceID); ok {
+			return card, "", nil
+		}
+	case models.ZoneTrash:
+		if card, ok := findCard(playerState.Trash, instanceID); ok {
+			return card, "", nil
+		}
+	case models.ZoneBattleArea:
+		pos, ok := findFriend(playerState, instanceID)
+		if !ok {
+			return models.CardInstance{}, "", fmt.Errorf("%s: %w", instanceID, ErrFriendNotFound)
+		}
+		return playerState.BattleArea[pos].Instance(), pos, nil
+	case models.ZoneEnergyArea:
+		for _, energy := range playerState.EnergyArea {
+			if energy.InstanceID == instanceID {
+				return energy.Instance(), "", nil
+			}
+		}
+	case models.ZoneNegativeEnergy:
+		for _, card := range playerState.NegativeEnergy {
+			if card.InstanceID == instanceID {
+				return card.Instance(), "", nil
+			}
+		}
+	case models.ZoneField:
+		if playerState.FieldCard != nil && playerState.FieldCard.InstanceID == instanceID {
+			return *playerState.FieldCard, "", nil
+		}
+	default:
+		return models.CardInstance{}, "", fmt.Errorf("unknown zone: %s", zone)
+	}
+	return models.CardInstance{}, "", fmt.Errorf("%s in %s: %w", instanceID, zone, ErrCardNotFound)
+}
+
+// removeFromZone takes a card found by zoneCard out of its zone. The order of
+// the other cards is kept.
+func removeFromZone(playerState *models.PlayerState, zone models.Zone, instanceID string, pos string) {
+	switch zone {
+	case models.ZoneDeck:
+		playerState.Deck, _, _ = removeCard(playerState.Deck, instanceID)
+	case models.ZoneHand:
+		playerState.Hand, _, _ = removeCard(playerState.Hand, instanceID)
+	case models.ZoneTrash:
+		playerState.Trash, _, _ = removeCard(playerState.Trash, instanceID)
+	case models.ZoneBattleArea:
+		delete(playerState.BattleArea, pos)
+	case models.ZoneEnergyArea:
+		for i, energy := range playerState.EnergyArea {
+			if energy.InstanceID == instanceID {
+				playerState.EnergyArea = append(playerState.EnergyArea[:i], playerState.EnergyArea[i+1:]...)
+				break
+			}
+		}
+	case models.ZoneNegativeEnergy:
+		playerState.NegativeEnergy, _, _ = playerState.NegativeEnergy.Remove(instanceID)
+	case models.ZoneField:
+		playerState.FieldCard = nil
+	}
+}
+
+// zoneEvent returns the leave or enter event of a zone move
+func (h *EventHandler) zoneEvent(eventType EventType, move ZoneMove, card models.CardInstance, pos string) GameEvent {
+	return GameEvent{
+		Type:       eventType,
+		Player:     move.Player,
+		CardNo:     card.CardNo,
+		InstanceID: card.InstanceID,
+		Target:     pos,
+		Phase:      h.game.CurrentPhase,
+		Data:       map[string]interface{}{"from": move.From, "to": move.To},
+	}
+}
+
+// emitAll raises events in order
+func (h *EventHandler) emitAll(events []GameEvent) error {
+	for _, event := range events {
+		if err := h.emit(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
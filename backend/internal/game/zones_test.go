@@ -0,0 +1,228 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+	
+	"mememe-tcg/internal/effects"
+	"mememe-tcg/internal/models"
+)
+
+// A move is rejected without changing anything unless the card is in the source
+// zone of the moving player's side and the destination can take it
+func TestMoveCardRejectsInvalidMoves(t *testing.T) {
+	tests := []struct {
+		name string
+		move ZoneMove
+	}{
+		{name: "not in the source zone", move: ZoneMove{Player: 1, InstanceID: "p1-d1", From: models.ZoneHand, To: models.ZoneTrash}},
+		{name: "opponent's card", move: ZoneMove{Player: 1, InstanceID: "p2-d1", From: models.ZoneDeck, To: models.ZoneHand}},
+		{name: "same zone", move: ZoneMove{Player: 1, InstanceID: "friend", From: models.ZoneHand, To: models.ZoneHand}},
+		{name: "invalid deck position", move: ZoneMove{Player: 1, InstanceID: "friend", From: models.ZoneHand, To: models.ZoneDeck, DeckPosition: "middle"}},
+		{name: "not a friend", move: ZoneMove{Player: 1, InstanceID: "support", From: models.ZoneHand, To: models.ZoneBattleArea}},
+		{name: "position taken", move: ZoneMove{Player: 1, InstanceID: "friend", From: models.ZoneHand, To: models.ZoneBattleArea, Position: "0"}},
+		{name: "invalid position", move: ZoneMove{Player: 1, InstanceID: "friend", From: models.ZoneHand, To: models.ZoneBattleArea, Position: "10"}},
+		{name: "not a field card", move: ZoneMove{Player: 1, InstanceID: "friend", From: models.ZoneHand, To: models.ZoneField}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+			g.GameState.Player1State.Hand = []models.CardInstance{{InstanceID: "friend", CardNo: "V-1000"}, {InstanceID: "support", CardNo: "V-S2"}}
+			g.GameState.Player1State.BattleArea["0"] = models.Friend{InstanceID: "in-play", CardNo: "V-1000", Power: 1000, TurnPlayed: 1}
+			before := reload(t, g)
+			h := NewEventHandler(g, loadTestCard)
+			
+			if err := h.MoveCard(tt.move); err == nil {
+				t.Fatalf("MoveCard succeeded")
+			}
+			if after := reload(t, g); !reflect.DeepEqual(after.GameState, before.GameState) {
+				t.Errorf("a rejected move changed the game")
+			}
+		})
+	}
+}
+
+func TestMoveCardToDeck(t *testing.T) {
+	tests := []struct {
+		name string
+		move ZoneMove
+		deck []string
+	}{
+		{name: "top", move: ZoneMove{InstanceID: "h1", From: models.ZoneHand, DeckPosition: models.DeckTop}, deck: []string{"h1", "p1-d1", "p1-d2", "p1-d3"}},
+		{name: "bottom", move: ZoneMove{InstanceID: "h1", From: models.ZoneHand, DeckPosition: models.DeckBottom}, deck: []string{"p1-d1", "p1-d2", "p1-d3", "h1"}},
+		{name: "bottom by default", move: ZoneMove{InstanceID: "h1", From: models.ZoneHand}, deck: []string{"p1-d1", "p1-d2", "p1-d3", "h1"}},
+		{name: "within the deck to the top", move: ZoneMove{InstanceID: "p1-d3", From: models.ZoneDeck, DeckPosition: models.DeckTop}, deck: []string{"p1-d3", "p1-d1", "p1-d2"}},
+		{name: "within the deck to the bottom", move: ZoneMove{InstanceID: "p1-d1", From: models.ZoneDeck, DeckPosition: models.DeckBottom}, deck: []string{"p1-d2", "p1-d3", "p1-d1"}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+			g.GameState.Player1State.Deck = g.GameState.Player1State.Deck[:3]
+			g.GameState.Player1State.Hand = []models.CardInstance{{InstanceID: "h1", CardNo: "V-1000"}}
+			h := NewEventHandler(g, loadTestCard)
+			
+			tt.move.Player, tt.move.To = 1, models.ZoneDeck
+			if err := h.MoveCard(tt.move); err != nil {
+				t.Fatalf("MoveCard: %v", err)
+			}
+			if got := instanceIDs(g.GameState.Player1State.Deck); !reflect.DeepEqual(got, tt.deck) {
+				t.Errorf("deck = %v, want %v", got, tt.deck)
+			}
+		})
+	}
+}
+
+// Leaving the battle area raises the "leaves play" trigger of the card that left
+// and ends the power modifiers on it. Leaving the hand does neither.
+func TestMoveCardLeavesPlay(t *testing.T) {
+	var log []string
+	registry := effects.NewEffectRegistry()
+	registry.Register("V-LOG", &logEffect{BaseEffect: effects.BaseEffect{Trigger: effects.TriggerOnLeavePlay}, log: &log})
+	
+	g := newTestGame()
+	playerState := &g.GameState.Player1State
+	playerState.BattleArea["0"] = models.Friend{InstanceID: "leaver", CardNo: "V-LOG", Power: 1000, TurnPlayed: 1}
+	playerState.BattleArea["1"] = models.Friend{InstanceID: "stayer", CardNo: "V-LOG", Power: 1000, TurnPlayed: 1}
+	playerState.Hand = []models.CardInstance{{InstanceID: "in-hand", CardNo: "V-LOG"}}
+	h := NewEventHandler(g, loadTestCard)
+	h.effectRegistry = registry
+	if err := h.ModifyPower(1, "leaver", 1000, models.DurationPermanent); err != nil {
+		t.Fatalf("ModifyPower: %v", err)
+	}
+	
+	if err := h.MoveCard(ZoneMove{Player: 1, InstanceID: "in-hand", From: models.ZoneHand, To: models.ZoneTrash}); err != nil {
+		t.Fatalf("MoveCard from the hand: %v", err)
+	}
+	if err := h.MoveCard(ZoneMove{Player: 1, InstanceID: "leaver", From: models.ZoneBattleArea, To: models.ZoneHand}); err != nil {
+		t.Fatalf("MoveCard from the battle area: %v", err)
+	}
+	
+	if want := []string{"leaver"}; !reflect.DeepEqual(log, want) {
+		t.Errorf("triggered %v, want %v", log, want)
+	}
+	if len(g.GameState.PowerModifiers) != 0 {
+		t.Errorf("modifiers %+v stayed on a friend that left play", g.GameState.PowerModifiers)
+	}
+	if got, want := instanceIDs(playerState.Hand), []string{"leaver"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("hand = %v, want %v", got, want)
+	}
+}
+
+// fillBattleArea puts a friend in every battle area position, "f0" to "f9"
+func fillBattleArea(playerState *models.PlayerState) {
+	for _, pos := range models.BattlePositions() {
+		playerState.BattleArea[pos] = models.Friend{InstanceID: "f" + pos, CardNo: "V-1000", Power: 1000, TurnPlayed: 1}
+	}
+}
+
+func TestValidatePosition(t *testing.T) {
+	tests := []struct {
+		name     string
+		full     bool
+		position string
+		rule     models.Rule // Empty when the position is accepted
+	}{
+		{name: "first free position", position: ""},
+		{name: "free position", position: "5"},
+		{name: "taken position", position: "0", rule: models.RuleInvalidPosition},
+		{name: "not a position", position: "10", rule: models.RuleInvalidPosition},
+		{name: "full without a position", full: true, position: "", rule: models.RuleBattleAreaFull},
+		{name: "full replacing a friend", full: true, position: "3"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+			g.GameState.Player1State.BattleArea["0"] = models.Friend{InstanceID: "f0", CardNo: "V-1000", Power: 1000, TurnPlayed: 1}
+			if tt.full {
+				fillBattleArea(&g.GameState.Player1State)
+			}
+			h := NewEventHandler(g, loadTestCard)
+			
+			if err := h.ValidatePosition(1, tt.position); ruleOf(err) != tt.rule {
+				t.Errorf("ValidatePosition = %v, want rule %q", err, tt.rule)
+			}
+			// Every free position, or every position when entering one replaces a friend
+			want := models.BattleAreaSize - 1
+			if tt.full {
+				want = models.BattleAreaSize
+			}
+			if positions, replace := h.entryPositions(1); len(positions) != want || replace != tt.full {
+				t.Errorf("entry positions = %v (replace %v), want %d of them", positions, replace, want)
+			}
+		})
+	}
+}
+
+// A friend entering a taken position replaces the friend there, which goes to the trash
+func TestMoveCardReplacesFriend(t *testing.T) {
+	g := newTestGame()
+	playerState := &g.GameState.Player1State
+	fillBattleArea(playerState)
+	playerState.Hand = []models.CardInstance{{InstanceID: "new", CardNo: "V-3000"}}
+	h := NewEventHandler(g, loadTestCard)
+	if err := h.ModifyPower(1, "f4", 1000, models.DurationPermanent); err != nil {
+		t.Fatalf("ModifyPower: %v", err)
+	}
+	
+	if err := h.MoveCard(ZoneMove{Player: 1, InstanceID: "new", From: models.ZoneHand, To: models.ZoneBattleArea, Position: "4", Replace: true}); err != nil {
+		t.Fatalf("MoveCard: %v", err)
+	}
+	
+	if friend := playerState.BattleArea["4"]; friend.InstanceID != "new" || friend.Power != 3000 || friend.TurnPlayed != g.CurrentTurn {
+		t.Errorf("position 4 holds %+v, want the new friend played this turn", friend)
+	}
+	if got, want := instanceIDs(playerState.Trash), []string{"f4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("trash = %v, want %v", got, want)
+	}
+	if len(playerState.BattleArea) != models.BattleAreaSize || len(g.GameState.PowerModifiers) != 0 {
+		t.Errorf("%d friends and modifiers %+v after the replacement", len(playerState.BattleArea), g.GameState.PowerModifiers)
+	}
+}
+
+// An effect that summons a friend into a full battle area asks which friend makes room
+func TestSummonIntoFullBattleArea(t *testing.T) {
+	registry := effects.NewEffectRegistry()
+	registry.Register("V-RISE", &effects.ReviveFriendEffect{BaseEffect: effects.BaseEffect{Trigger: effects.TriggerMain}})
+	
+	g := newTestGame()
+	playerState := &g.GameState.Player1State
+	fillBattleArea(playerState)
+	playerState.Trash = []models.CardInstance{{InstanceID: "revived", CardNo: "V-3000"}}
+	h := NewEventHandler(g, loadTestCard)
+	h.effectRegistry = registry
+	h.resolver.Push([]EffectStackItem{{
+		Effect:   registry.GetAbilities("V-RISE")[0].Effect,
+		Source:   testCards["V-RISE"],
+		SourceID: "revive",
+		Player:   1,
+	}})
+	if err := h.resolver.ResolveAll(); err != nil {
+		t.Fatalf("ResolveAll: %v", err)
+	}
+	
+	pending := g.GameState.PendingEffect
+	if pending == nil || pending.Choice.Kind != models.ChoiceKindReplace {
+		t.Fatalf("no replacement choice is pending")
+	}
+	if len(pending.Choice.Options) != models.BattleAreaSize || pending.Choice.Options[7].ID != "f7" {
+		t.Fatalf("options = %+v, want every friend in position order", pending.Choice.Options)
+	}
+	
+	g = reload(t, g)
+	h = NewEventHandler(g, loadTestCard)
+	h.effectRegistry = registry
+	if err := h.ResolveEffectChoice(1, pending.Choice.ID, []int{7}); err != nil {
+		t.Fatalf("ResolveEffectChoice: %v", err)
+	}
+	
+	playerState = &g.GameState.Player1State
+	if friend := playerState.BattleArea["7"]; friend.InstanceID != "revived" {
+		t.Errorf("position 7 holds %+v, want the revived friend", friend)
+	}
+	if got, want := instanceIDs(playerState.Trash), []string{"f7"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("trash = %v, want %v", got, want)
+	}
+}
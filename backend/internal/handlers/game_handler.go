@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"errors"
+	"mememe-tcg/internal/database"
+	"mememe-tcg/internal/models"
+	"mememe-tcg/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GameHandler struct {
+	gameService *services.GameService
+}
+
+func NewGameHandler() *GameHandler {
+	return &GameHandler{
+		gameService: services.NewGameService(database.GetDB(), services.NewCardService()),
+	}
+}
+
+type CreateGameRequest struct {
+	Player1ID uint  `json:"player1_id" binding:"required"`
+	Player2ID uint  `json:"player2_id" binding:"required"`
+	Deck1ID   uint  `json:"deck1_id" binding:"required"`
+	Deck2ID   uint  `json:"deck2_id" binding:"required"`
+	Seed      int64 `json:"seed"` // Optional, replays a previous game when set
+}
+
+type PlayCardRequest struct {
+	PlayerID       uint     `json:"player_id" binding:"required"`
+	InstanceID     string   `json:"instance_id" binding:"required"` // Instance ID of the card in hand
+	Position       string   `json:"position"`                       // Battle area position "0" to "9" for a friend, the first free one if empty
+	Targets        []string `json:"targets"`                        // Instance IDs of the chosen targets
+	Energy         []int    `json:"energy"`                         // Indices of the energy cards to rest as payment
+	NegativeEnergy []int    `json:"negative_energy"`                // Indices of the face-up negative energy cards to turn face down as payment
+}
+
+type ActivateAbilityRequest struct {
+	PlayerID   uint   `json:"player_id" binding:"required"`
+	InstanceID string `json:"instance_id" binding:"required"` // Instance ID of the friend or field card
+	Ability    int    `json:"ability"`                        // Index of the ability on the card
+	Energy     []int  `json:"energy"`                         // Indices of the energy cards to rest as payment
+}
+
+type AttackRequest struct {
+	PlayerID    uint   `json:"player_id" binding:"required"`
+	AttackerPos string `json:"attacker_pos" binding:"required"`
+	TargetPos   string `json:"target_pos"`
+}
+
+type BlockRequest struct {
+	PlayerID    uint   `json:"player_id" binding:"required"`
+	BlockerPos  string `json:"blocker_pos" binding:"required"`
+	AttackerPos string `json:"attacker_pos"`
+}
+
+type MulliganRequest struct {
+	PlayerID uint `json:"player_id" binding:"required"`
+	Redraw   bool `json:"redraw"`
+}
+
+type PassRequest struct {
+	PlayerID uint `json:"player_id" binding:"required"`
+}
+
+type PhaseRequest struct {
+	PlayerID uint `json:"player_id" binding:"required"`
+}
+
+type PhaseChoiceRequest struct {
+	PlayerID          uint     `json:"player_id" binding:"required"`
+	UseNegativeEnergy bool     `json:"use_negative_energy"`
+	Discard           []string `json:"discard"` // Instance IDs of the cards to discard
+}
+
+type EffectChoiceRequest struct {
+	PlayerID  uint   `json:"player_id" binding:"required"`
+	ChoiceID  string `json:"choice_id" binding:"required"`
+	Selection []int  `json:"selection"` // Indices of the picked options
+}
+
+func (h *GameHandler) CreateGame(c *gin.Context) {
+	var req CreateGameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	game, err := h.gameService.CreateGame(req.Player1ID, req.Player2ID, req.Deck1ID, req.Deck2ID, req.Seed)
+	if err != nil {
+		respondGameError(c, err)
+		return
+	}
+
+	// Neither player has drawn yet, so the creator gets a spectator's view
+	c.JSON(http.StatusCreated, game.ViewFor(0))
+}
+
+// GetGame returns the game as the player_id query parameter's player sees it,
+// or as a spectator sees it without one
+func (h *GameHandler) GetGame(c *gin.Context) {
+	var playerID uint64
+	if query := c.Query("player_id"); query != "" {
+		var err error
+		playerID, err = strconv.ParseUint(query, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID"})
+			return
+		}
+	}
+
+	game, err := h.gameService.GetGame(c.Param("id"))
+	respondGame(c, game, uint(playerID), err)
+}
+
+func (h *GameHandler) GetLegalActions(c *gin.Context) {
+	playerID, err := strconv.ParseUint(c.Query("player_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID"})
+		return
+	}
+
+	actions, err := h.gameService.GetLegalActions(c.Param("id"), uint(playerID))
+	if err != nil {
+		respondGameError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, actions)
+}
+
+func (h *GameHandler) Mulligan(c *gin.Context) {
+	var req MulliganRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	game, err := h.gameService.Mulligan(c.Param("id"), req.PlayerID, req.Redraw)
+	respondGame(c, game, req.PlayerID, err)
+}
+
+func (h *GameHandler) PlayCard(c *gin.Context) {
+	var req PlayCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	game, err := h.gameService.PlayCard(c.Param("id"), req.PlayerID, req.InstanceID, req.Position, req.Targets, req.Energy, req.NegativeEnergy)
+	respondGame(c, game, req.PlayerID, err)
+}
+
+func (h *GameHandler) ActivateAbility(c *gin.Context) {
+	var req ActivateAbilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	game, err := h.gameService.ActivateAbility(c.Param("id"), req.PlayerID, req.InstanceID, req.Ability, req.Energy)
+	respondGame(c, game, req.PlayerID, err)
+}
+
+func (h *GameHandler) Attack(c *gin.Context) {
+	var req AttackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	game, err := h.gameService.Attack(c.Param("id"), req.PlayerID, req.AttackerPos, req.TargetPos)
+	respondGame(c, game, req.PlayerID, err)
+}
+
+func (h *GameHandler) Block(c *gin.Context) {
+	var req BlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	game, err := h.gameService.Block(c.Param("id"), req.PlayerID, req.BlockerPos, req.AttackerPos)
+	respondGame(c, game, req.PlayerID, err)
+}
+
+func (h *GameHandler) Pass(c *gin.Context) {
+	var req PassRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	game, err := h.gameService.Pass(c.Param("id"), req.PlayerID)
+	respondGame(c, game, req.PlayerID, err)
+}
+
+func (h *GameHandler) ChangePhase(c *gin.Context) {
+	var req PhaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	game, err := h.gameService.ChangePhase(c.Param("id"), req.PlayerID)
+	respondGame(c, game, req.PlayerID, err)
+}
+
+func (h *GameHandler) ResolvePhaseChoice(c *gin.Context) {
+	var req PhaseChoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	game, err := h.gameService.ResolvePhaseChoice(c.Param("id"), req.PlayerID, req.UseNegativeEnergy, req.Discard)
+	respondGame(c, game, req.PlayerID, err)
+}
+
+func (h *GameHandler) ResolveEffectChoice(c *gin.Context) {
+	var req EffectChoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	game, err := h.gameService.ResolveEffectChoice(c.Param("id"), req.PlayerID, req.ChoiceID, req.Selection)
+	respondGame(c, game, req.PlayerID, err)
+}
+
+// respondGame writes the game as the player sees it, or the error that rejected the request
+func respondGame(c *gin.Context, game *models.Game, playerID uint, err error) {
+	if err != nil {
+		respondGameError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, game.ViewFor(playerID))
+}
+
+// respondGameError maps a game service error to its status code: 404 for a missing
+// game or deck, 409 for a finished game, 400 for a rejected action or an illegal
+// deck, and 500 for anything else
+func respondGameError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrGameNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		return
+	}
+	if errors.Is(err, services.ErrDeckNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Deck not found"})
+		return
+	}
+
+	var finishedErr *models.GameFinishedError
+	if errors.As(err, &finishedErr) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "winner_id": finishedErr.WinnerID})
+		return
+	}
+
+	var violation *models.RuleViolation
+	if errors.As(err, &violation) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "rule": violation.Rule})
+		return
+	}
+
+	var validationErr *models.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
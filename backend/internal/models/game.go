@@ -1,7 +1,9 @@
 package models
 
 import (
+	"fmt"
 	"gorm.io/gorm"
+	"strconv"
 	"time"
 )
 
@@ -18,10 +20,19 @@ const (
 
 const (
 	StatusWaiting  GameStatus = "waiting"
+	StatusMulligan GameStatus = "mulligan" // Both players decide whether to redraw their opening hand
 	StatusPlaying  GameStatus = "playing"
 	StatusFinished GameStatus = "finished"
 )
 
+// AttackStep is the window an attack is waiting in before it resolves
+type AttackStep string
+
+const (
+	AttackStepBlock   AttackStep = "block"   // Defender may declare a blocker
+	AttackStepCounter AttackStep = "counter" // Defender may use counter cards
+)
+
 type Game struct {
 	gorm.Model
 	GameID        string       `json:"game_id" gorm:"uniqueIndex"`
@@ -34,33 +45,405 @@ type Game struct {
 	WinnerID      *uint        `json:"winner_id,omitempty"`
 	StartedAt     *time.Time   `json:"started_at,omitempty"`
 	FinishedAt    *time.Time   `json:"finished_at,omitempty"`
+	Seed          int64        `json:"seed,omitempty"` // RNG seed for shuffles, allows exact replays; hidden until the game is finished
 	GameState     *GameState   `json:"game_state,omitempty" gorm:"serializer:json"`
 }
 
+// ViewFor returns the game as a player may see it. Until the game is finished the
+// opponent's hand, the cards and order of both decks, the seed and the starting
+// decks are hidden; a hidden card is sent as an empty card instance so the zone
+// sizes stay visible. A player ID not in the game gets a spectator's view.
+func (g *Game) ViewFor(playerID uint) *Game {
+	if g.Status == StatusFinished {
+		return g
+	}
+	
+	view := *g
+	view.Seed = 0
+	if g.GameState == nil {
+		return &view
+	}
+	
+	state := *g.GameState
+	state.ShuffleCount = 0
+	state.Player1State = g.GameState.Player1State.hide(g.Player1ID == playerID)
+	state.Player2State = g.GameState.Player2State.hide(g.Player2ID == playerID)
+	view.GameState = &state
+	return &view
+}
+
+// GameFinishedError is returned when an action is attempted on a finished game
+type GameFinishedError struct {
+	GameID   string
+	WinnerID *uint
+}
+
+func (e *GameFinishedError) Error() string {
+	if e.WinnerID == nil {
+		return fmt.Sprintf("game %s is finished (draw)", e.GameID)
+	}
+	return fmt.Sprintf("game %s is finished (winner: %d)", e.GameID, *e.WinnerID)
+}
+
+// Rule identifies a game rule an action can break
+type Rule string
+
+const (
+	RuleNotYourTurn       Rule = "not_your_turn"
+	RuleWrongPhase        Rule = "wrong_phase"
+	RuleAttackInProgress  Rule = "attack_in_progress"
+	RuleChoicePending     Rule = "choice_pending"
+	RuleCardNotInHand     Rule = "card_not_in_hand"
+	RuleNoFriend          Rule = "no_friend"
+	RuleFriendRested      Rule = "friend_rested"
+	RuleSummoningSickness Rule = "summoning_sickness"
+	RuleNoAttack          Rule = "no_attack"
+	RuleNotDefender       Rule = "not_defender"
+	RuleWrongAttackStep   Rule = "wrong_attack_step"
+	RuleNotInPlay         Rule = "not_in_play"
+	RuleNoAbility         Rule = "no_ability"
+	RuleAbilityUsed       Rule = "ability_used"
+	RuleCannotPay         Rule = "cannot_pay"
+	RuleNotCounter        Rule = "not_counter"
+	RuleCounterOnly       Rule = "counter_only"
+	RuleInvalidTarget     Rule = "invalid_target"
+	RuleInvalidPosition   Rule = "invalid_position"
+	RuleBattleAreaFull    Rule = "battle_area_full"
+	RuleNotAPlayer        Rule = "not_a_player"
+	RuleWrongStatus       Rule = "wrong_status"
+	RuleMulliganDecided   Rule = "mulligan_decided"
+)
+
+// RuleViolation is returned when an action is not legal in the current game state
+type RuleViolation struct {
+	Rule    Rule
+	Message string
+}
+
+func (e *RuleViolation) Error() string {
+	return e.Message
+}
+
 type GameState struct {
-	Player1State PlayerState `json:"player1_state"`
-	Player2State PlayerState `json:"player2_state"`
+	Player1State       PlayerState      `json:"player1_state"`
+	Player2State       PlayerState      `json:"player2_state"`
+	ShuffleCount       int              `json:"shuffle_count,omitempty"` // Number of shuffles performed, used to derive each shuffle from the seed
+	PendingAttack      *PendingAttack   `json:"pending_attack,omitempty"`
+	PendingPhaseChoice *PhaseChoice     `json:"pending_phase_choice,omitempty"` // Automatic phase step waiting for a decision
+	PowerModifiers     []PowerModifier  `json:"power_modifiers,omitempty"`      // Power changes applied by resolved effects
+	DelayedTriggers    []DelayedTrigger `json:"delayed_triggers,omitempty"`     // Effects waiting to happen at a later timing
+	PendingEffect      *PendingEffect   `json:"pending_effect,omitempty"`       // Effect resolution paused for a player's choice
+	ChoiceCount        int              `json:"choice_count"`                   // Number of effect choices asked, used for choice IDs
+	AbilityUses        []AbilityUse     `json:"ability_uses,omitempty"`         // Once-per-turn abilities used this turn
 }
 
+// Zone is an area of a player's side of the game that holds cards
+type Zone string
+
+const (
+	ZoneDeck           Zone = "deck"
+	ZoneHand           Zone = "hand"
+	ZoneBattleArea     Zone = "battle_area"
+	ZoneEnergyArea     Zone = "energy_area"
+	ZoneNegativeEnergy Zone = "negative_energy"
+	ZoneTrash          Zone = "trash"
+	ZoneField          Zone = "field"
+)
+
+// InPlay reports whether cards in the zone are in play
+func (z Zone) InPlay() bool {
+	return z == ZoneBattleArea || z == ZoneField
+}
+
+// BattleAreaSize is the number of friends a player can have in play.
+// Battle area positions are "0" to "9".
+const BattleAreaSize = 10
+
+// BattlePositions returns the battle area positions in order
+func BattlePositions() []string {
+	positions := make([]string, BattleAreaSize)
+	for i := range positions {
+		positions[i] = strconv.Itoa(i)
+	}
+	return positions
+}
+
+// IsBattlePosition reports whether pos is a battle area position
+func IsBattlePosition(pos string) bool {
+	i, err := strconv.Atoi(pos)
+	return err == nil && i >= 0 && i < BattleAreaSize && strconv.Itoa(i) == pos
+}
+
+// DeckPosition is the end of the deck a card is put on
+type DeckPosition string
+
+const (
+	DeckTop    DeckPosition = "top"
+	DeckBottom DeckPosition = "bottom"
+)
+
 type PlayerState struct {
-	Deck             []string          `json:"deck"`
-	Hand             []string          `json:"hand"`
+	Deck             []CardInstance    `json:"deck"` // Index 0 is the top of the deck
+	Hand             []CardInstance    `json:"hand"`
 	BattleArea       map[string]Friend `json:"battle_area"`
 	EnergyArea       []EnergyCard      `json:"energy_area"`
-	NegativeEnergy   []string          `json:"negative_energy"`
-	Trash            []string          `json:"trash"`
-	FieldCard        *string           `json:"field_card,omitempty"`
+	NegativeEnergy   NegativeEnergyZone `json:"negative_energy"`
+	Trash            []CardInstance    `json:"trash"`
+	FieldCard        *CardInstance     `json:"field_card,omitempty"`
+	MulliganDecided  bool              `json:"mulligan_decided"`
+	StartingDeck     []CardInstance    `json:"starting_deck,omitempty"` // Deck before the first shuffle, replayed with the game seed
 }
 
-type Friend struct {
+// hide returns a copy of the player state without the cards another player may not see
+func (p PlayerState) hide(showHand bool) PlayerState {
+	p.Deck = make([]CardInstance, len(p.Deck))
+	if !showHand {
+		p.Hand = make([]CardInstance, len(p.Hand))
+	}
+	p.StartingDeck = nil
+	return p
+}
+
+// PendingAttack is an attack that has been declared but not yet resolved
+type PendingAttack struct {
+	AttackerPlayer int        `json:"attacker_player"`
+	AttackerPos    string     `json:"attacker_pos"`
+	TargetPos      string     `json:"target_pos,omitempty"` // Empty when attacking the player directly
+	BlockerPos     string     `json:"blocker_pos,omitempty"`
+	Step           AttackStep `json:"step"`
+}
+
+// PhaseChoiceKind is the decision a phase is waiting for
+type PhaseChoiceKind string
+
+const (
+	PhaseChoiceEnergySource PhaseChoiceKind = "energy_source" // Place energy from the deck or reveal negative energy instead (F-090)
+	PhaseChoiceDiscard      PhaseChoiceKind = "discard"       // Discard down to the hand limit
+)
+
+// PhaseChoice is an automatic phase step paused for the active player's decision
+type PhaseChoice struct {
+	Player int             `json:"player"`
+	Kind   PhaseChoiceKind `json:"kind"`
+	Count  int             `json:"count,omitempty"` // Number of cards to discard
+}
+
+// ChoiceKind is the kind of decision an effect asks a player to make
+type ChoiceKind string
+
+const (
+	ChoiceKindTarget  ChoiceKind = "target"  // Pick cards among the options
+	ChoiceKindOption  ChoiceKind = "option"  // Pick one of several named options, e.g. "top" or "bottom"
+	ChoiceKindOrder   ChoiceKind = "order"   // Put simultaneous triggered effects in the order they resolve
+	ChoiceKindConfirm ChoiceKind = "confirm" // Use an optional ("できる") effect or decline it
+	ChoiceKindReplace ChoiceKind = "replace" // Pick a friend to put in the trash to make room in a full battle area
+)
+
+// TargetType is the kind of thing a target refers to
+type TargetType string
+
+const (
+	TargetFriend         TargetType = "friend"          // A friend in a battle area
+	TargetCard           TargetType = "card"            // A card in a deck, hand or trash
+	TargetEnergy         TargetType = "energy"          // A card in an energy area
+	TargetNegativeEnergy TargetType = "negative_energy" // A card in a negative energy area
+	TargetField          TargetType = "field"           // A field card
+	TargetOption         TargetType = "option"          // A named option, e.g. "top" or "bottom"
+)
+
+// Target refers to a card an effect can pick, by its owner, zone and instance ID,
+// or to a named option. It is also one thing a player can pick in an effect choice.
+type Target struct {
+	Type   TargetType `json:"type"`
+	Player int        `json:"player,omitempty"` // Player whose zone the card is in
+	Zone   Zone       `json:"zone,omitempty"`
+	Slot   string     `json:"slot,omitempty"` // Battle area position, or index in the energy or negative energy area
+	ID     string     `json:"id"`             // Instance ID of a card, or the name of an option
+	CardNo string     `json:"card_no,omitempty"`
+	Label  string     `json:"label,omitempty"`
+}
+
+// Is reports whether two targets refer to the same card or option
+func (t Target) Is(other Target) bool {
+	return t.Type == other.Type && t.Player == other.Player && t.Zone == other.Zone && t.ID == other.ID
+}
+
+// EffectChoice is a decision a player must make before an effect can go on resolving.
+// The selection is a list of option indices, between Min and Max of them. Once
+// the deadline has passed, the default selection is used.
+type EffectChoice struct {
+	ID          string         `json:"id"`
+	Player      int            `json:"player"`
+	Kind        ChoiceKind     `json:"kind"`
+	CardNo      string         `json:"card_no"`   // Card whose effect asks
+	SourceID    string         `json:"source_id"` // Instance ID of that card
+	Description string         `json:"description"`
+	Options     []Target       `json:"options"`
+	Min         int            `json:"min"`
+	Max         int            `json:"max"`
+	Default     []int          `json:"default"`
+	Deadline    time.Time      `json:"deadline"`
+}
+
+// StackEntry is an effect waiting on the effect stack. Its effect is looked up
+// again from the card number and ability index when resolution resumes.
+type StackEntry struct {
+	CardNo   string       `json:"card_no"`
+	Ability  int          `json:"ability"`
+	SourceID string       `json:"source_id"`
+	Player   int          `json:"player"`
+	Targets  []Target     `json:"targets,omitempty"`  // Targets picked when the card was played
+	Choices  [][]int      `json:"choices,omitempty"`  // Selections already made for this effect
+	Accepted *bool        `json:"accepted,omitempty"`  // Answer to an optional effect's prompt
+	Replace  string       `json:"replace,omitempty"`   // Friend picked to make room in a full battle area
+	CostPaid bool         `json:"cost_paid,omitempty"` // The ability's cost was paid when it was activated
+	Group    []StackEntry `json:"group,omitempty"`   // Simultaneous effects the player has yet to order
+}
+
+// AbilityUse records that a card used a once-per-turn ability
+type AbilityUse struct {
+	SourceID string `json:"source_id"` // Instance ID of the card
+	Ability  int    `json:"ability"`   // Index of the ability among the card's abilities
+	Turn     int    `json:"turn"`
+}
+
+// ResumeStep is the automatic game step that continues once a paused effect stack has resolved
+type ResumeStep string
+
+const (
+	ResumePhaseStep ResumeStep = "phase_step" // Run the current phase's automatic step
+	ResumePhaseEnd  ResumeStep = "phase_end"  // Move on to the next phase
+	ResumeTurnEnd   ResumeStep = "turn_end"   // Pass the turn to the other player
+	ResumeTurnStart ResumeStep = "turn_start" // Start the new turn's phases
+)
+
+// PendingEffect is effect resolution paused until a player makes a choice
+type PendingEffect struct {
+	Choice EffectChoice `json:"choice"`
+	Stack  []StackEntry `json:"stack"`            // Effects waiting to resolve, bottom of the stack first
+	Resume ResumeStep   `json:"resume,omitempty"` // Empty when the paused action had already finished
+}
+
+// EffectDuration is how long an applied effect lasts
+type EffectDuration string
+
+const (
+	DurationTurn           EffectDuration = "turn"            // Until the end of the turn it was applied
+	DurationUntilEndPhase  EffectDuration = "until_end_phase" // Until the end phase of the turn begins
+	DurationWhileCondition EffectDuration = "while_condition" // While the source is in play and its condition holds
+	DurationPermanent      EffectDuration = "permanent"       // While the friend stays in the battle area
+)
+
+// PowerModifier is a power change an effect applied to one friend. It is kept
+// apart from the friend's base power and expires with its duration.
+type PowerModifier struct {
+	TargetID string         `json:"target_id"` // Instance ID of the friend
+	SourceID string         `json:"source_id"` // Instance ID of the card that applied it
+	Amount   int            `json:"amount"`
+	Duration EffectDuration `json:"duration"`
+	Turn     int            `json:"turn"` // Turn the modifier was applied
+}
+
+// DelayedTiming is when a delayed trigger fires
+type DelayedTiming string
+
+const (
+	TimingEndPhase DelayedTiming = "end_phase" // During the end phase, before the turn ends
+	TimingTurnEnd  DelayedTiming = "turn_end"  // When the turn ends
+)
+
+// DelayedAction is what a delayed trigger does when it fires
+type DelayedAction string
+
+const (
+	DelayedDestroy DelayedAction = "destroy" // Destroy the target friend
+)
+
+// DelayedTrigger is an effect registered now that happens at a later timing,
+// e.g. "the friend played by this effect is destroyed in the end phase"
+type DelayedTrigger struct {
+	Player   int           `json:"player"`    // Player who controls the target
+	SourceID string        `json:"source_id"` // Instance ID of the card that registered it
+	Action   DelayedAction `json:"action"`
+	TargetID string        `json:"target_id"` // Instance ID of the affected card
+	Timing   DelayedTiming `json:"timing"`
+	Turn     int           `json:"turn"` // Turn the trigger was registered, the only turn it fires in
+}
+
+// CardInstance is one physical card in a game. Its InstanceID is unique within
+// the game and stays the same while the card moves between zones.
+type CardInstance struct {
+	InstanceID string `json:"instance_id"`
 	CardNo     string `json:"card_no"`
-	Power      int    `json:"power"`
-	IsRest     bool   `json:"is_rest"`
-	TurnPlayed int    `json:"turn_played"`
+}
+
+type Friend struct {
+	InstanceID     string `json:"instance_id"`
+	CardNo         string `json:"card_no"`
+	Power          int    `json:"power"`           // Base power, never changed by effects
+	EffectivePower int    `json:"effective_power"` // Power with all modifiers, recomputed after every action
+	IsRest         bool   `json:"is_rest"`
+	TurnPlayed     int    `json:"turn_played"`
+}
+
+// Instance returns the card object of the friend
+func (f Friend) Instance() CardInstance {
+	return CardInstance{InstanceID: f.InstanceID, CardNo: f.CardNo}
 }
 
 type EnergyCard struct {
-	CardNo string `json:"card_no"`
-	Color  CardColor `json:"color"`
-	IsRest bool   `json:"is_rest"`
+	InstanceID string    `json:"instance_id"`
+	CardNo     string    `json:"card_no"`
+	Color      CardColor `json:"color"`
+	IsRest     bool      `json:"is_rest"`
+}
+
+// Instance returns the card object of the energy card
+func (e EnergyCard) Instance() CardInstance {
+	return CardInstance{InstanceID: e.InstanceID, CardNo: e.CardNo}
+}
+
+// NegativeEnergyCard is a card in a player's negative energy area. Damage puts
+// cards there face down; effects turn them face up, and face-up cards can pay
+// costs like energy, which turns them face down again.
+type NegativeEnergyCard struct {
+	InstanceID   string    `json:"instance_id"`
+	CardNo       string    `json:"card_no"`
+	Color        CardColor `json:"color"`
+	FaceUp       bool      `json:"face_up"`
+	RevealedTurn int       `json:"revealed_turn,omitempty"` // Turn the card was last turned face up
+}
+
+// Instance returns the card object of the negative energy card
+func (n NegativeEnergyCard) Instance() CardInstance {
+	return CardInstance{InstanceID: n.InstanceID, CardNo: n.CardNo}
+}
+
+// NegativeEnergyZone is a player's negative energy area, oldest card first
+type NegativeEnergyZone []NegativeEnergyCard
+
+// FaceUpCount returns the number of face-up cards in the zone
+func (z NegativeEnergyZone) FaceUpCount() int {
+	count := 0
+	for _, card := range z {
+		if card.FaceUp {
+			count++
+		}
+	}
+	return count
+}
+
+// FaceDownCount returns the number of face-down cards in the zone
+func (z NegativeEnergyZone) FaceDownCount() int {
+	return len(z) - z.FaceUpCount()
+}
+
+// Remove takes a card out of the zone
+func (z NegativeEnergyZone) Remove(instanceID string) (NegativeEnergyZone, NegativeEnergyCard, bool) {
+	for i, card := range z {
+		if card.InstanceID == instanceID {
+			return append(z[:i:i], z[i+1:]...), card, true
+		}
+	}
+	return z, NegativeEnergyCard{}, false
 }
\ No newline at end of file
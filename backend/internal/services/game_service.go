@@ -1,49 +1,67 @@
 package services
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
-	"github.com/your-org/card_game/backend/internal/game"
-	"github.com/your-org/card_game/backend/internal/models"
+	"sync"
+	"time"
+	"mememe-tcg/internal/game"
+	"mememe-tcg/internal/models"
 	"gorm.io/gorm"
 )
 
+var (
+	// ErrGameNotFound is returned when no game has the requested game ID
+	ErrGameNotFound = errors.New("game not found")
+	// ErrDeckNotFound is returned when a game is created from a deck that does not exist
+	ErrDeckNotFound = errors.New("deck not found")
+)
+
 type GameService struct {
-	db           *gorm.DB
-	cardService  *CardService
-	eventHandler map[string]*game.EventHandler // game_id -> event handler
+	db          *gorm.DB
+	cardService *CardService
+	gameLocks   map[string]*gameLock // game_id -> lock held from loading a game to saving it
+	mu          sync.Mutex
+}
+
+// gameLock is the lock of one game, kept only while requests on the game hold or wait for it
+type gameLock struct {
+	sync.Mutex
+	users int // Requests holding or waiting for the lock
 }
 
 func NewGameService(db *gorm.DB, cardService *CardService) *GameService {
 	return &GameService{
-		db:           db,
-		cardService:  cardService,
-		eventHandler: make(map[string]*game.EventHandler),
+		db:          db,
+		cardService: cardService,
+		gameLocks:   make(map[string]*gameLock),
 	}
 }
 
-// CreateGame creates a new game
-func (s *GameService) CreateGame(player1ID, player2ID uint, deck1ID, deck2ID uint) (*models.Game, error) {
+// CreateGame creates a new game from two decks, shuffles them and deals the opening hands.
+// A seed of 0 picks a random seed; the seed used is stored on the game so it can be replayed.
+func (s *GameService) CreateGame(player1ID, player2ID uint, deck1ID, deck2ID uint, seed int64) (*models.Game, error) {
 	gameID := generateGameID()
 	
+	// Load both decks
+	deck1, err := s.loadDeck(deck1ID)
+	if err != nil {
+		return nil, err
+	}
+	deck2, err := s.loadDeck(deck2ID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	
 	// Initialize game state
 	gameState := &models.GameState{
-		Player1State: models.PlayerState{
-			Deck:           []string{}, // Will be populated from deck
-			Hand:           []string{},
-			BattleArea:     make(map[string]models.Friend),
-			EnergyArea:     []models.EnergyCard{},
-			NegativeEnergy: []string{},
-			Trash:          []string{},
-		},
-		Player2State: models.PlayerState{
-			Deck:           []string{},
-			Hand:           []string{},
-			BattleArea:     make(map[string]models.Friend),
-			EnergyArea:     []models.EnergyCard{},
-			NegativeEnergy: []string{},
-			Trash:          []string{},
-		},
+		Player1State: game.NewPlayerState(game.ExpandDeck(1, deck1)),
+		Player2State: game.NewPlayerState(game.ExpandDeck(2, deck2)),
 	}
 	
 	// Create game
@@ -54,388 +72,711 @@ func (s *GameService) CreateGame(player1ID, player2ID uint, deck1ID, deck2ID uin
 		CurrentTurn:  1,
 		CurrentPhase: models.PhaseStart,
 		ActivePlayer: 1,
-		Status:       models.StatusWaiting,
+		Status:       models.StatusMulligan,
+		Seed:         seed,
 		GameState:    gameState,
 	}
 	
+	// Shuffle and deal opening hands
+	game.DealOpeningHands(newGame)
+	
 	if err := s.db.Create(newGame).Error; err != nil {
 		return nil, err
 	}
 	
-	// Create event handler for this game
-	s.eventHandler[gameID] = game.NewEventHandler(newGame)
-	
 	return newGame, nil
 }
 
-// PlayCard plays a card from hand
-func (s *GameService) PlayCard(gameID string, playerID uint, cardNo string, position string, targets []string) error {
+// Mulligan records a player's decision to keep or redraw their opening hand.
+// Each player decides once; the first turn begins when both have decided.
+func (s *GameService) Mulligan(gameID string, playerID uint, redraw bool) (*models.Game, error) {
+	defer s.lockGame(gameID)()
+	
 	// Get game
-	var gameModel models.Game
-	if err := s.db.Where("game_id = ?", gameID).First(&gameModel).Error; err != nil {
-		return err
+	gameModel, err := s.loadGameForAction(gameID)
+	if err != nil {
+		return nil, err
 	}
 	
-	// Get event handler
-	handler, exists := s.eventHandler[gameID]
-	if !exists {
-		handler = game.NewEventHandler(&gameModel)
-		s.eventHandler[gameID] = handler
+	if err := requireNotFinished(gameModel); err != nil {
+		return nil, err
+	}
+	if gameModel.Status != models.StatusMulligan {
+		return nil, &models.RuleViolation{Rule: models.RuleWrongStatus, Message: "game is not in the mulligan step"}
 	}
 	
 	// Determine which player
-	var player int
-	if gameModel.Player1ID == playerID {
-		player = 1
-	} else if gameModel.Player2ID == playerID {
-		player = 2
-	} else {
-		return fmt.Errorf("player not in this game")
+	player, err := getPlayerNumber(gameModel, playerID)
+	if err != nil {
+		return nil, err
+	}
+	
+	playerState := getPlayerState(gameModel, player)
+	if playerState.MulliganDecided {
+		return nil, &models.RuleViolation{Rule: models.RuleMulliganDecided, Message: "mulligan already decided"}
+	}
+	
+	if redraw {
+		game.RedrawOpeningHand(gameModel, playerState)
+	}
+	playerState.MulliganDecided = true
+	
+	// Start the first turn once both players have decided
+	if gameModel.GameState.Player1State.MulliganDecided && gameModel.GameState.Player2State.MulliganDecided {
+		now := time.Now()
+		gameModel.Status = models.StatusPlaying
+		gameModel.StartedAt = &now
+		
+		if err := s.getEventHandler(gameModel).StartTurn(); err != nil {
+			return nil, err
+		}
+	}
+	
+	// Save game state
+	if err := s.db.Save(gameModel).Error; err != nil {
+		return nil, err
 	}
 	
+	return gameModel, nil
+}
+
+// GetGame returns the current state of a game. It never changes the game: an
+// expired effect choice stays pending until the next action answers it.
+func (s *GameService) GetGame(gameID string) (*models.Game, error) {
+	defer s.lockGame(gameID)()
+	
+	return s.loadGame(gameID)
+}
+
+// PlayCard plays a card from hand, paying its cost by resting the selected energy cards
+// and turning the selected face-up negative energy cards face down.
+// The defending player plays counter cards with it during an attack's counter window.
+// Targets are instance IDs picked for a support card's effect; without them the
+// player is asked to choose when the effect resolves.
+func (s *GameService) PlayCard(gameID string, playerID uint, instanceID string, position string, targets []string, energy []int, negativeEnergy []int) (*models.Game, error) {
+	defer s.lockGame(gameID)()
+	
+	// Get game
+	gameModel, err := s.loadGameForAction(gameID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := requirePlaying(gameModel); err != nil {
+		return nil, err
+	}
+	
+	// Get event handler
+	handler := s.getEventHandler(gameModel)
+	
+	// Determine which player
+	player, err := getPlayerNumber(gameModel, playerID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := handler.ValidatePlayCard(player, instanceID, position); err != nil {
+		return nil, err
+	}
+	if err := handler.ValidateTargets(player, instanceID, targets); err != nil {
+		return nil, err
+	}
+	instance := findHandCard(gameModel, player, instanceID)
+	
 	// Get card details
-	card, err := s.cardService.GetCardByNo(cardNo)
+	card, err := s.cardService.GetCardByNumber(instance.CardNo)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	
+	// Pay the cost
+	if err := handler.PayCost(player, card, energy, negativeEnergy); err != nil {
+		return nil, err
 	}
 	
 	// Play the card based on type
 	switch card.Type {
 	case models.CardTypeFriend:
-		if err := s.playFriend(&gameModel, player, cardNo, position); err != nil {
-			return err
+		if err := handler.MoveCard(game.ZoneMove{
+			Player:     player,
+			InstanceID: instance.InstanceID,
+			From:       models.ZoneHand,
+			To:         models.ZoneBattleArea,
+			Position:   position,
+			Replace:    handler.BattleAreaFull(player),
+		}); err != nil {
+			return nil, err
 		}
 		
 		// Trigger friend played event
 		event := game.GameEvent{
-			Type:   game.EventFriendPlayed,
-			Player: player,
-			CardNo: cardNo,
-			Phase:  gameModel.CurrentPhase,
+			Type:       game.EventFriendPlayed,
+			Player:     player,
+			CardNo:     instance.CardNo,
+			InstanceID: instance.InstanceID,
+			Phase:      gameModel.CurrentPhase,
 		}
 		if err := handler.TriggerEvent(event); err != nil {
-			return err
+			return nil, err
 		}
 		
 	case models.CardTypeSupport:
-		if err := s.playSupport(&gameModel, player, cardNo, targets); err != nil {
-			return err
+		// Support cards go to trash after use
+		if err := handler.MoveCard(game.ZoneMove{
+			Player:     player,
+			InstanceID: instance.InstanceID,
+			From:       models.ZoneHand,
+			To:         models.ZoneTrash,
+		}); err != nil {
+			return nil, err
 		}
 		
 		// Trigger support played event
 		event := game.GameEvent{
-			Type:   game.EventSupportPlayed,
-			Player: player,
-			CardNo: cardNo,
-			Phase:  gameModel.CurrentPhase,
+			Type:       game.EventSupportPlayed,
+			Player:     player,
+			CardNo:     instance.CardNo,
+			InstanceID: instance.InstanceID,
+			Phase:      gameModel.CurrentPhase,
 			Data: map[string]interface{}{
 				"targets": targets,
 			},
 		}
 		if err := handler.TriggerEvent(event); err != nil {
-			return err
+			return nil, err
 		}
 		
 	case models.CardTypeField:
-		if err := s.playField(&gameModel, player, cardNo); err != nil {
-			return err
+		// The previous field card goes to trash
+		if err := handler.MoveCard(game.ZoneMove{
+			Player:     player,
+			InstanceID: instance.InstanceID,
+			From:       models.ZoneHand,
+			To:         models.ZoneField,
+		}); err != nil {
+			return nil, err
 		}
 		
 		// Trigger field played event
 		event := game.GameEvent{
-			Type:   game.EventFieldPlayed,
-			Player: player,
-			CardNo: cardNo,
-			Phase:  gameModel.CurrentPhase,
+			Type:       game.EventFieldPlayed,
+			Player:     player,
+			CardNo:     instance.CardNo,
+			InstanceID: instance.InstanceID,
+			Phase:      gameModel.CurrentPhase,
 		}
 		if err := handler.TriggerEvent(event); err != nil {
-			return err
+			return nil, err
 		}
 	}
 	
+	// Finish the game if a loss condition was reached
+	game.CheckVictory(gameModel)
+	
+	// Recompute continuous effects for the new state
+	handler.RefreshPower()
+	
 	// Save game state
-	return s.db.Save(&gameModel).Error
+	if err := s.db.Save(gameModel).Error; err != nil {
+		return nil, err
+	}
+	
+	return gameModel, nil
 }
 
-// Attack performs an attack with a friend
-func (s *GameService) Attack(gameID string, playerID uint, attackerPos string, targetPos string) error {
+// ActivateAbility uses a 【メイン】 ability of a friend or field card the player controls.
+// The energy indices pay the ability's energy cost; nil picks them automatically.
+func (s *GameService) ActivateAbility(gameID string, playerID uint, instanceID string, ability int, energy []int) (*models.Game, error) {
+	defer s.lockGame(gameID)()
+	
 	// Get game
-	var gameModel models.Game
-	if err := s.db.Where("game_id = ?", gameID).First(&gameModel).Error; err != nil {
-		return err
+	gameModel, err := s.loadGameForAction(gameID)
+	if err != nil {
+		return nil, err
 	}
 	
-	// Get event handler
-	handler, exists := s.eventHandler[gameID]
-	if !exists {
-		handler = game.NewEventHandler(&gameModel)
-		s.eventHandler[gameID] = handler
+	if err := requirePlaying(gameModel); err != nil {
+		return nil, err
 	}
 	
+	// Get event handler
+	handler := s.getEventHandler(gameModel)
+	
 	// Determine which player
-	var player int
-	if gameModel.Player1ID == playerID {
-		player = 1
-	} else if gameModel.Player2ID == playerID {
-		player = 2
-	} else {
-		return fmt.Errorf("player not in this game")
+	player, err := getPlayerNumber(gameModel, playerID)
+	if err != nil {
+		return nil, err
 	}
 	
-	// Get attacker
-	var playerState *models.PlayerState
-	if player == 1 {
-		playerState = &gameModel.GameState.Player1State
-	} else {
-		playerState = &gameModel.GameState.Player2State
+	if err := handler.ActivateAbility(player, instanceID, ability, energy); err != nil {
+		return nil, err
 	}
 	
-	attacker, exists := playerState.BattleArea[attackerPos]
-	if !exists {
-		return fmt.Errorf("no friend at position %s", attackerPos)
+	// Finish the game if a loss condition was reached
+	game.CheckVictory(gameModel)
+	
+	// Recompute continuous effects for the new state
+	handler.RefreshPower()
+	
+	// Save game state
+	if err := s.db.Save(gameModel).Error; err != nil {
+		return nil, err
+	}
+	
+	return gameModel, nil
+}
+
+// Attack declares an attack with a friend. The attacker is rested and the defender
+// gets a block window, then a counter window, before the battle resolves.
+// An empty targetPos attacks the opponent directly.
+func (s *GameService) Attack(gameID string, playerID uint, attackerPos string, targetPos string) (*models.Game, error) {
+	defer s.lockGame(gameID)()
+	
+	// Get game
+	gameModel, err := s.loadGameForAction(gameID)
+	if err != nil {
+		return nil, err
 	}
 	
-	// Trigger attack event
-	event := game.GameEvent{
-		Type:   game.EventFriendAttacks,
-		Player: player,
-		CardNo: attacker.CardNo,
-		Target: targetPos,
-		Phase:  gameModel.CurrentPhase,
+	if err := requirePlaying(gameModel); err != nil {
+		return nil, err
 	}
-	if err := handler.TriggerEvent(event); err != nil {
-		return err
+	
+	// Get event handler
+	handler := s.getEventHandler(gameModel)
+	
+	// Determine which player
+	player, err := getPlayerNumber(gameModel, playerID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := handler.DeclareAttack(player, attackerPos, targetPos); err != nil {
+		return nil, err
 	}
 	
-	// Perform attack logic
-	// TODO: Implement attack resolution
+	// Finish the game if a loss condition was reached
+	game.CheckVictory(gameModel)
+	
+	// Recompute continuous effects for the new state
+	handler.RefreshPower()
 	
 	// Save game state
-	return s.db.Save(&gameModel).Error
+	if err := s.db.Save(gameModel).Error; err != nil {
+		return nil, err
+	}
+	
+	return gameModel, nil
 }
 
-// Block declares a blocker for an attack
-func (s *GameService) Block(gameID string, playerID uint, blockerPos string, attackerPos string) error {
+// Block declares a blocker for the pending attack and opens the counter window
+func (s *GameService) Block(gameID string, playerID uint, blockerPos string, attackerPos string) (*models.Game, error) {
+	defer s.lockGame(gameID)()
+	
 	// Get game
-	var gameModel models.Game
-	if err := s.db.Where("game_id = ?", gameID).First(&gameModel).Error; err != nil {
-		return err
+	gameModel, err := s.loadGameForAction(gameID)
+	if err != nil {
+		return nil, err
 	}
 	
-	// Get event handler
-	handler, exists := s.eventHandler[gameID]
-	if !exists {
-		handler = game.NewEventHandler(&gameModel)
-		s.eventHandler[gameID] = handler
+	if err := requirePlaying(gameModel); err != nil {
+		return nil, err
 	}
 	
+	// Get event handler
+	handler := s.getEventHandler(gameModel)
+	
 	// Determine which player
-	var player int
-	if gameModel.Player1ID == playerID {
-		player = 1
-	} else if gameModel.Player2ID == playerID {
-		player = 2
-	} else {
-		return fmt.Errorf("player not in this game")
+	player, err := getPlayerNumber(gameModel, playerID)
+	if err != nil {
+		return nil, err
 	}
 	
-	// Get blocker
-	var playerState *models.PlayerState
-	if player == 1 {
-		playerState = &gameModel.GameState.Player1State
-	} else {
-		playerState = &gameModel.GameState.Player2State
+	attack := gameModel.GameState.PendingAttack
+	if attack != nil && attackerPos != "" && attack.AttackerPos != attackerPos {
+		return nil, &models.RuleViolation{Rule: models.RuleNoAttack, Message: fmt.Sprintf("friend at position %s is not attacking", attackerPos)}
 	}
 	
-	blocker, exists := playerState.BattleArea[blockerPos]
-	if !exists {
-		return fmt.Errorf("no friend at position %s", blockerPos)
+	if err := handler.DeclareBlock(player, blockerPos); err != nil {
+		return nil, err
+	}
+	
+	// Finish the game if a loss condition was reached
+	game.CheckVictory(gameModel)
+	
+	// Recompute continuous effects for the new state
+	handler.RefreshPower()
+	
+	// Save game state
+	if err := s.db.Save(gameModel).Error; err != nil {
+		return nil, err
 	}
 	
-	// Trigger block event
-	event := game.GameEvent{
-		Type:   game.EventFriendBlocks,
-		Player: player,
-		CardNo: blocker.CardNo,
-		Target: attackerPos,
-		Phase:  gameModel.CurrentPhase,
+	return gameModel, nil
+}
+
+// Pass declines the defender's current block or counter window.
+// Passing the counter window resolves the battle.
+func (s *GameService) Pass(gameID string, playerID uint) (*models.Game, error) {
+	defer s.lockGame(gameID)()
+	
+	// Get game
+	gameModel, err := s.loadGameForAction(gameID)
+	if err != nil {
+		return nil, err
 	}
-	if err := handler.TriggerEvent(event); err != nil {
-		return err
+	
+	if err := requirePlaying(gameModel); err != nil {
+		return nil, err
 	}
 	
-	// Perform block logic
-	// TODO: Implement block resolution
+	// Get event handler
+	handler := s.getEventHandler(gameModel)
+	
+	// Determine which player
+	player, err := getPlayerNumber(gameModel, playerID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := handler.PassAttackStep(player); err != nil {
+		return nil, err
+	}
+	
+	// Finish the game if a loss condition was reached
+	game.CheckVictory(gameModel)
+	
+	// Recompute continuous effects for the new state
+	handler.RefreshPower()
 	
 	// Save game state
-	return s.db.Save(&gameModel).Error
+	if err := s.db.Save(gameModel).Error; err != nil {
+		return nil, err
+	}
+	
+	return gameModel, nil
 }
 
-// ChangePhase moves to the next phase
-func (s *GameService) ChangePhase(gameID string, playerID uint) error {
+// ChangePhase ends the active player's current phase. The server runs the
+// automatic phase steps itself and stops at the next main phase, or earlier
+// when a phase needs the player's decision.
+func (s *GameService) ChangePhase(gameID string, playerID uint) (*models.Game, error) {
+	defer s.lockGame(gameID)()
+	
 	// Get game
-	var gameModel models.Game
-	if err := s.db.Where("game_id = ?", gameID).First(&gameModel).Error; err != nil {
-		return err
+	gameModel, err := s.loadGameForAction(gameID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := requirePlaying(gameModel); err != nil {
+		return nil, err
 	}
 	
 	// Get event handler
-	handler, exists := s.eventHandler[gameID]
-	if !exists {
-		handler = game.NewEventHandler(&gameModel)
-		s.eventHandler[gameID] = handler
+	handler := s.getEventHandler(gameModel)
+	
+	// Determine which player
+	player, err := getPlayerNumber(gameModel, playerID)
+	if err != nil {
+		return nil, err
 	}
 	
-	// Trigger phase end event
-	endEvent := game.GameEvent{
-		Type:   game.EventPhaseEnd,
-		Player: gameModel.ActivePlayer,
-		Phase:  gameModel.CurrentPhase,
+	if err := handler.ValidateEndPhase(player); err != nil {
+		return nil, err
 	}
-	if err := handler.TriggerEvent(endEvent); err != nil {
-		return err
+	
+	if err := handler.AdvancePhase(); err != nil {
+		return nil, err
 	}
 	
-	// Move to next phase
-	switch gameModel.CurrentPhase {
-	case models.PhaseStart:
-		gameModel.CurrentPhase = models.PhaseDraw
-	case models.PhaseDraw:
-		gameModel.CurrentPhase = models.PhaseEnergy
-	case models.PhaseEnergy:
-		gameModel.CurrentPhase = models.PhaseMain
-	case models.PhaseMain:
-		gameModel.CurrentPhase = models.PhaseEnd
-	case models.PhaseEnd:
-		// End turn
-		gameModel.CurrentPhase = models.PhaseStart
-		gameModel.CurrentTurn++
-		if gameModel.ActivePlayer == 1 {
-			gameModel.ActivePlayer = 2
-		} else {
-			gameModel.ActivePlayer = 1
-		}
-		
-		// Trigger turn end event
-		turnEndEvent := game.GameEvent{
-			Type:   game.EventTurnEnd,
-			Player: gameModel.ActivePlayer,
-			Phase:  gameModel.CurrentPhase,
-		}
-		handler.TriggerEvent(turnEndEvent)
-		
-		// Trigger turn start event
-		turnStartEvent := game.GameEvent{
-			Type:   game.EventTurnStart,
-			Player: gameModel.ActivePlayer,
-			Phase:  gameModel.CurrentPhase,
-		}
-		handler.TriggerEvent(turnStartEvent)
+	// Finish the game if a loss condition was reached
+	game.CheckVictory(gameModel)
+	
+	// Recompute continuous effects for the new state
+	handler.RefreshPower()
+	
+	// Save game state
+	if err := s.db.Save(gameModel).Error; err != nil {
+		return nil, err
+	}
+	
+	return gameModel, nil
+}
+
+// ResolvePhaseChoice answers the decision a phase is waiting for: whether to
+// reveal negative energy instead of placing energy (F-090), or which cards to
+// discard down to the hand limit. The remaining phase steps then continue.
+func (s *GameService) ResolvePhaseChoice(gameID string, playerID uint, useNegativeEnergy bool, discard []string) (*models.Game, error) {
+	defer s.lockGame(gameID)()
+	
+	// Get game
+	gameModel, err := s.loadGameForAction(gameID)
+	if err != nil {
+		return nil, err
 	}
 	
-	// Trigger phase start event
-	startEvent := game.GameEvent{
-		Type:   game.EventPhaseStart,
-		Player: gameModel.ActivePlayer,
-		Phase:  gameModel.CurrentPhase,
+	if err := requirePlaying(gameModel); err != nil {
+		return nil, err
 	}
-	if err := handler.TriggerEvent(startEvent); err != nil {
-		return err
+	
+	// Get event handler
+	handler := s.getEventHandler(gameModel)
+	
+	// Determine which player
+	player, err := getPlayerNumber(gameModel, playerID)
+	if err != nil {
+		return nil, err
+	}
+	
+	choice := gameModel.GameState.PendingPhaseChoice
+	if choice == nil {
+		return nil, &models.RuleViolation{Rule: models.RuleChoicePending, Message: "no phase choice is pending"}
+	}
+	
+	switch choice.Kind {
+	case models.PhaseChoiceEnergySource:
+		err = handler.ResolveEnergyChoice(player, useNegativeEnergy)
+	case models.PhaseChoiceDiscard:
+		err = handler.ResolveDiscardChoice(player, discard)
 	}
+	if err != nil {
+		return nil, err
+	}
+	
+	// Finish the game if a loss condition was reached
+	game.CheckVictory(gameModel)
+	
+	// Recompute continuous effects for the new state
+	handler.RefreshPower()
 	
 	// Save game state
-	return s.db.Save(&gameModel).Error
+	if err := s.db.Save(gameModel).Error; err != nil {
+		return nil, err
+	}
+	
+	return gameModel, nil
 }
 
-// Helper methods
-
-func (s *GameService) playFriend(game *models.Game, player int, cardNo string, position string) error {
-	var playerState *models.PlayerState
-	if player == 1 {
-		playerState = &game.GameState.Player1State
-	} else {
-		playerState = &game.GameState.Player2State
+// ResolveEffectChoice answers the choice a resolving effect is waiting for with
+// the indices of the picked options. The effect stack then resolves on.
+func (s *GameService) ResolveEffectChoice(gameID string, playerID uint, choiceID string, selection []int) (*models.Game, error) {
+	defer s.lockGame(gameID)()
+	
+	// Get game
+	gameModel, err := s.loadGameForAction(gameID)
+	if err != nil {
+		return nil, err
 	}
 	
-	// Remove from hand
-	for i, card := range playerState.Hand {
-		if card == cardNo {
-			playerState.Hand = append(playerState.Hand[:i], playerState.Hand[i+1:]...)
-			break
-		}
+	if err := requirePlaying(gameModel); err != nil {
+		return nil, err
 	}
 	
-	// Get card details
-	card, err := s.cardService.GetCardByNo(cardNo)
+	// Get event handler
+	handler := s.getEventHandler(gameModel)
+	
+	// Determine which player
+	player, err := getPlayerNumber(gameModel, playerID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	
-	// Add to battle area
-	friend := models.Friend{
-		CardNo:     cardNo,
-		Power:      card.Power,
-		IsRest:     false, // Friends enter active by default
-		TurnPlayed: game.CurrentTurn,
+	if err := handler.ResolveEffectChoice(player, choiceID, selection); err != nil {
+		return nil, err
 	}
 	
-	// Check for effects that modify entry state
-	// TODO: Check for "enter rested" effects
+	// Finish the game if a loss condition was reached
+	game.CheckVictory(gameModel)
 	
-	playerState.BattleArea[position] = friend
+	// Recompute continuous effects for the new state
+	handler.RefreshPower()
 	
-	return nil
+	// Save game state
+	if err := s.db.Save(gameModel).Error; err != nil {
+		return nil, err
+	}
+	
+	return gameModel, nil
 }
 
-func (s *GameService) playSupport(game *models.Game, player int, cardNo string, targets []string) error {
-	var playerState *models.PlayerState
-	if player == 1 {
-		playerState = &game.GameState.Player1State
-	} else {
-		playerState = &game.GameState.Player2State
+// GetLegalActions lists what a player can do in the current game state
+func (s *GameService) GetLegalActions(gameID string, playerID uint) (*game.LegalActions, error) {
+	defer s.lockGame(gameID)()
+	
+	// Get game
+	gameModel, err := s.loadGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := requireNotFinished(gameModel); err != nil {
+		return nil, err
+	}
+	
+	// Get event handler
+	handler := s.getEventHandler(gameModel)
+	
+	// Determine which player
+	player, err := getPlayerNumber(gameModel, playerID)
+	if err != nil {
+		return nil, err
 	}
 	
-	// Remove from hand
-	for i, card := range playerState.Hand {
-		if card == cardNo {
-			playerState.Hand = append(playerState.Hand[:i], playerState.Hand[i+1:]...)
-			break
+	return handler.GetLegalActions(player), nil
+}
+
+// Helper methods
+
+// loadGame loads a game without changing it, for requests that only read it
+func (s *GameService) loadGame(gameID string) (*models.Game, error) {
+	var gameModel models.Game
+	if err := s.db.Where("game_id = ?", gameID).First(&gameModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("game %s: %w", gameID, ErrGameNotFound)
 		}
+		return nil, err
+	}
+	return &gameModel, nil
+}
+
+// loadGameForAction loads a game for an action. An effect choice whose deadline
+// has passed is answered first, so the action sees the game as it now stands.
+func (s *GameService) loadGameForAction(gameID string) (*models.Game, error) {
+	gameModel, err := s.loadGame(gameID)
+	if err != nil {
+		return nil, err
 	}
 	
-	// Support cards go to trash after use
-	playerState.Trash = append(playerState.Trash, cardNo)
+	if err := s.expireEffectChoice(gameModel); err != nil {
+		return nil, err
+	}
+	return gameModel, nil
+}
+
+// expireEffectChoice answers an effect choice nobody answered in time with its
+// default selection and saves the result
+func (s *GameService) expireEffectChoice(gameModel *models.Game) error {
+	if gameModel.GameState == nil || gameModel.GameState.PendingEffect == nil {
+		return nil
+	}
 	
-	return nil
+	handler := s.getEventHandler(gameModel)
+	expired, err := handler.ExpireEffectChoice(time.Now())
+	if err != nil || !expired {
+		return err
+	}
+	
+	// Finish the game if a loss condition was reached
+	game.CheckVictory(gameModel)
+	
+	// Recompute continuous effects for the new state
+	handler.RefreshPower()
+	
+	return s.db.Save(gameModel).Error
 }
 
-func (s *GameService) playField(game *models.Game, player int, cardNo string) error {
-	var playerState *models.PlayerState
-	if player == 1 {
-		playerState = &game.GameState.Player1State
-	} else {
-		playerState = &game.GameState.Player2State
+// lockGame makes the other requests on a game wait until the returned function
+// is called, so that each action loads, changes and saves the game on its own.
+// The lock is removed once no request holds or waits for it.
+func (s *GameService) lockGame(gameID string) func() {
+	s.mu.Lock()
+	lock, exists := s.gameLocks[gameID]
+	if !exists {
+		lock = &gameLock{}
+		s.gameLocks[gameID] = lock
 	}
+	lock.users++
+	s.mu.Unlock()
 	
-	// Remove from hand
-	for i, card := range playerState.Hand {
-		if card == cardNo {
-			playerState.Hand = append(playerState.Hand[:i], playerState.Hand[i+1:]...)
-			break
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		
+		s.mu.Lock()
+		lock.users--
+		if lock.users == 0 {
+			delete(s.gameLocks, gameID)
 		}
+		s.mu.Unlock()
 	}
-	
-	// If there's already a field card, it goes to trash
-	if playerState.FieldCard != nil {
-		playerState.Trash = append(playerState.Trash, *playerState.FieldCard)
+}
+
+// getEventHandler returns a new event handler for a freshly loaded game model
+func (s *GameService) getEventHandler(gameModel *models.Game) *game.EventHandler {
+	return game.NewEventHandler(gameModel, s.cardService.GetCardByNumber)
+}
+
+// loadDeck loads a deck and checks that it is legal to play
+func (s *GameService) loadDeck(deckID uint) (*models.Deck, error) {
+	var deck models.Deck
+	// A fixed card order gives the same instance IDs and, with the seed, the same shuffles
+	orderCards := func(db *gorm.DB) *gorm.DB {
+		return db.Where("deleted_at IS NULL").Order("card_no, id")
+	}
+	if err := s.db.Preload("Cards", orderCards).First(&deck, deckID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("deck %d: %w", deckID, ErrDeckNotFound)
+		}
+		return nil, fmt.Errorf("deck %d: %w", deckID, err)
 	}
 	
-	// Set new field card
-	playerState.FieldCard = &cardNo
+	if err := deck.Validate(); err != nil {
+		return nil, fmt.Errorf("deck %d: %w", deckID, err)
+	}
 	
+	return &deck, nil
+}
+
+// requirePlaying rejects actions before the first turn has started
+func requirePlaying(gameModel *models.Game) error {
+	if err := requireNotFinished(gameModel); err != nil {
+		return err
+	}
+	if gameModel.Status != models.StatusPlaying {
+		return &models.RuleViolation{Rule: models.RuleWrongStatus, Message: fmt.Sprintf("game is not in progress (status: %s)", gameModel.Status)}
+	}
 	return nil
 }
 
+func requireNotFinished(gameModel *models.Game) error {
+	if gameModel.Status == models.StatusFinished {
+		return &models.GameFinishedError{GameID: gameModel.GameID, WinnerID: gameModel.WinnerID}
+	}
+	return nil
+}
+
+func getPlayerState(gameModel *models.Game, player int) *models.PlayerState {
+	if player == 1 {
+		return &gameModel.GameState.Player1State
+	}
+	return &gameModel.GameState.Player2State
+}
+
+func getPlayerNumber(gameModel *models.Game, playerID uint) (int, error) {
+	if gameModel.Player1ID == playerID {
+		return 1, nil
+	} else if gameModel.Player2ID == playerID {
+		return 2, nil
+	}
+	return 0, &models.RuleViolation{Rule: models.RuleNotAPlayer, Message: "player not in this game"}
+}
+
+// findHandCard returns a card instance from a player's hand
+func findHandCard(game *models.Game, player int, instanceID string) models.CardInstance {
+	playerState := &game.GameState.Player1State
+	if player == 2 {
+		playerState = &game.GameState.Player2State
+	}
+	
+	for _, card := range playerState.Hand {
+		if card.InstanceID == instanceID {
+			return card
+		}
+	}
+	return models.CardInstance{InstanceID: instanceID}
+}
+
 func generateGameID() string {
 	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 	b := make([]byte, 10)
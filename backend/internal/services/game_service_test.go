@@ -0,0 +1,282 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"testing"
+	
+	"mememe-tcg/internal/database"
+	"mememe-tcg/internal/game"
+	"mememe-tcg/internal/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestService returns a game service on a fresh database holding one legal
+// 50-card deck of friends without effects
+func newTestService(t *testing.T) (*GameService, uint) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	database.DB = db
+	if err := db.AutoMigrate(&models.Card{}, &models.Deck{}, &models.DeckCard{}, &models.Game{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	
+	deck := models.Deck{Name: "test", UserID: 1}
+	for i := 1; i <= 13; i++ {
+		card := models.Card{CardNo: fmt.Sprintf("V-%02d", i), Name: "test", Type: models.CardTypeFriend, Color: models.ColorRed, Cost: 1, Power: 1000, Rarity: models.RarityC}
+		if err := db.Create(&card).Error; err != nil {
+			t.Fatalf("create card: %v", err)
+		}
+		quantity := 4
+		if i == 13 {
+			quantity = 2
+		}
+		deck.Cards = append(deck.Cards, models.DeckCard{CardNo: card.CardNo, Quantity: quantity})
+	}
+	if err := db.Create(&deck).Error; err != nil {
+		t.Fatalf("create deck: %v", err)
+	}
+	
+	return NewGameService(db, NewCardService()), deck.ID
+}
+
+func handIDs(playerState models.PlayerState) []string {
+	ids := []string{}
+	for _, card := range playerState.Hand {
+		ids = append(ids, card.InstanceID)
+	}
+	return ids
+}
+
+func TestMulligan(t *testing.T) {
+	tests := []struct {
+		name   string
+		redraw bool
+	}{
+		{name: "keep", redraw: false},
+		{name: "redraw", redraw: true},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, deckID := newTestService(t)
+			created, err := svc.CreateGame(1, 2, deckID, deckID, 42)
+			if err != nil {
+				t.Fatalf("CreateGame: %v", err)
+			}
+			opening := handIDs(created.GameState.Player1State)
+			
+			gameModel, err := svc.Mulligan(created.GameID, 1, tt.redraw)
+			if err != nil {
+				t.Fatalf("Mulligan: %v", err)
+			}
+			playerState := gameModel.GameState.Player1State
+			if got := len(playerState.Hand); got != 5 {
+				t.Errorf("hand size = %d, want 5", got)
+			}
+			if got := len(playerState.Hand) + len(playerState.Deck); got != 50 {
+				t.Errorf("hand and deck hold %d cards, want 50", got)
+			}
+			if kept := reflect.DeepEqual(handIDs(playerState), opening); kept == tt.redraw {
+				t.Errorf("hand kept = %v after redraw = %v", kept, tt.redraw)
+			}
+		})
+	}
+}
+
+func TestMulliganOncePerPlayer(t *testing.T) {
+	svc, deckID := newTestService(t)
+	created, err := svc.CreateGame(1, 2, deckID, deckID, 42)
+	if err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	
+	gameModel, err := svc.Mulligan(created.GameID, 1, false)
+	if err != nil {
+		t.Fatalf("player 1 Mulligan: %v", err)
+	}
+	if gameModel.Status != models.StatusMulligan {
+		t.Errorf("status = %s after one decision, want %s", gameModel.Status, models.StatusMulligan)
+	}
+	if _, err := svc.Mulligan(created.GameID, 1, true); err == nil {
+		t.Errorf("player 1 decided twice")
+	}
+	if _, err := svc.Pass(created.GameID, 1); err == nil {
+		t.Errorf("an action was accepted before the first turn started")
+	}
+	
+	gameModel, err = svc.Mulligan(created.GameID, 2, true)
+	if err != nil {
+		t.Fatalf("player 2 Mulligan: %v", err)
+	}
+	if gameModel.Status != models.StatusPlaying {
+		t.Errorf("status = %s after both decisions, want %s", gameModel.Status, models.StatusPlaying)
+	}
+	if gameModel.CurrentTurn != 1 || gameModel.ActivePlayer != 1 {
+		t.Errorf("turn %d for player %d, want turn 1 for player 1", gameModel.CurrentTurn, gameModel.ActivePlayer)
+	}
+	if _, err := svc.Mulligan(created.GameID, 2, false); err == nil {
+		t.Errorf("a mulligan was accepted after the first turn started")
+	}
+}
+
+func TestGameViewHidesHiddenCards(t *testing.T) {
+	svc, deckID := newTestService(t)
+	gameModel, err := svc.CreateGame(1, 2, deckID, deckID, 42)
+	if err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	ownHand := handIDs(gameModel.GameState.Player1State)
+	
+	view := gameModel.ViewFor(1)
+	if got := handIDs(view.GameState.Player1State); !reflect.DeepEqual(got, ownHand) {
+		t.Errorf("own hand = %v, want %v", got, ownHand)
+	}
+	hidden := []models.CardInstance{}
+	hidden = append(hidden, view.GameState.Player2State.Hand...)
+	hidden = append(hidden, view.GameState.Player1State.Deck...)
+	hidden = append(hidden, view.GameState.Player2State.Deck...)
+	if len(hidden) != 5+45+45 {
+		t.Errorf("%d hidden cards, want the zone sizes kept", len(hidden))
+	}
+	for _, card := range hidden {
+		if card != (models.CardInstance{}) {
+			t.Fatalf("hidden card %+v is visible", card)
+		}
+	}
+	
+	data, err := json.Marshal(view)
+	if err != nil {
+		t.Fatalf("marshal view: %v", err)
+	}
+	for _, field := range []string{`"seed"`, `"shuffle_count"`, `"starting_deck"`} {
+		if strings.Contains(string(data), field) {
+			t.Errorf("view contains %s", field)
+		}
+	}
+	
+	// The view is a copy; the game itself keeps every card
+	if gameModel.Seed != 42 || len(gameModel.GameState.Player2State.StartingDeck) != 50 || gameModel.GameState.Player2State.Hand[0].InstanceID == "" {
+		t.Errorf("making a view changed the game")
+	}
+	
+	gameModel.Status = models.StatusFinished
+	if view := gameModel.ViewFor(1); view.Seed != 42 || view.GameState.Player2State.Hand[0].InstanceID == "" {
+		t.Errorf("a finished game hides cards")
+	}
+}
+
+func TestGameServiceErrors(t *testing.T) {
+	svc, deckID := newTestService(t)
+	created, err := svc.CreateGame(1, 2, deckID, deckID, 42)
+	if err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	
+	if _, err := svc.CreateGame(1, 2, deckID, deckID+1, 42); !errors.Is(err, ErrDeckNotFound) {
+		t.Errorf("CreateGame with a missing deck = %v, want %v", err, ErrDeckNotFound)
+	}
+	if _, err := svc.GetGame("missing"); !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("GetGame of a missing game = %v, want %v", err, ErrGameNotFound)
+	}
+	
+	var violation *models.RuleViolation
+	if _, err := svc.Mulligan(created.GameID, 3, false); !errors.As(err, &violation) || violation.Rule != models.RuleNotAPlayer {
+		t.Errorf("Mulligan by a player not in the game = %v, want rule %q", err, models.RuleNotAPlayer)
+	}
+}
+
+func TestGameLocksAreRemoved(t *testing.T) {
+	svc, deckID := newTestService(t)
+	created, err := svc.CreateGame(1, 2, deckID, deckID, 42)
+	if err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			svc.GetGame(created.GameID)
+		}()
+	}
+	wg.Wait()
+	
+	if got := len(svc.gameLocks); got != 0 {
+		t.Errorf("%d game locks left after every request returned", got)
+	}
+}
+
+// Reading a game never answers an expired effect choice; the next action does
+func TestExpiredChoiceIsAnsweredByActions(t *testing.T) {
+	svc, deckID := newTestService(t)
+	created, err := svc.CreateGame(1, 2, deckID, deckID, 42)
+	if err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	for _, playerID := range []uint{1, 2} {
+		if _, err := svc.Mulligan(created.GameID, playerID, false); err != nil {
+			t.Fatalf("Mulligan: %v", err)
+		}
+	}
+	
+	gameModel, err := svc.GetGame(created.GameID)
+	if err != nil {
+		t.Fatalf("GetGame: %v", err)
+	}
+	// F-042 asks which trash card to return to the deck
+	if err := svc.db.Create(&models.Card{CardNo: "F-042", Name: "test", Type: models.CardTypeFriend, Color: models.ColorYellow, Cost: 2, Power: 2000, Rarity: models.RarityC}).Error; err != nil {
+		t.Fatalf("create card: %v", err)
+	}
+	playerState := &gameModel.GameState.Player1State
+	playerState.Trash = []models.CardInstance{{InstanceID: "t1", CardNo: "V-01"}, {InstanceID: "t2", CardNo: "V-02"}, {InstanceID: "t3", CardNo: "V-03"}}
+	playerState.BattleArea["0"] = models.Friend{InstanceID: "ukki", CardNo: "F-042", Power: 2000, TurnPlayed: 1}
+	if err := svc.getEventHandler(gameModel).TriggerEvent(game.GameEvent{Type: game.EventFriendPlayed, Player: 1, CardNo: "F-042", InstanceID: "ukki", Phase: gameModel.CurrentPhase}); err != nil {
+		t.Fatalf("TriggerEvent: %v", err)
+	}
+	if gameModel.GameState.PendingEffect == nil {
+		t.Fatalf("F-042 did not ask for a choice")
+	}
+	gameModel.GameState.PendingEffect.Choice.Deadline = time.Now().Add(-time.Minute)
+	if err := svc.db.Save(gameModel).Error; err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	
+	for i := 0; i < 2; i++ {
+		if _, err := svc.GetLegalActions(created.GameID, 1); err != nil {
+			t.Fatalf("GetLegalActions: %v", err)
+		}
+		gameModel, err = svc.GetGame(created.GameID)
+		if err != nil {
+			t.Fatalf("GetGame: %v", err)
+		}
+		if gameModel.GameState.PendingEffect == nil {
+			t.Fatalf("reading the game answered the expired choice")
+		}
+	}
+	
+	gameModel, err = svc.ChangePhase(created.GameID, 1)
+	if err != nil {
+		t.Fatalf("ChangePhase: %v", err)
+	}
+	if gameModel.GameState.PendingEffect != nil {
+		t.Errorf("the expired choice is still pending after an action")
+	}
+	if got := len(gameModel.GameState.Player1State.Trash); got != 3 {
+		t.Errorf("%d trash cards left, want the default answer to return none", got)
+	}
+}
@@ -1,5 +1,5 @@
 import axios from 'axios'
-import type { Card, Deck, DeckCard } from '@/types'
+import type { Card, Deck, DeckCard, Game, LegalActions } from '@/types'
 
 const api = axios.create({
   baseURL: '/api/v1',
@@ -74,4 +74,100 @@ export const deckService = {
   },
 }
 
+export const gameService = {
+  async createGame(player1Id: number, player2Id: number, deck1Id: number, deck2Id: number, seed?: number): Promise<Game> {
+    const response = await api.post('/games', {
+      player1_id: player1Id,
+      player2_id: player2Id,
+      deck1_id: deck1Id,
+      deck2_id: deck2Id,
+      seed,
+    })
+    return response.data
+  },
+
+  async getGame(gameId: string): Promise<Game> {
+    const response = await api.get(`/games/${gameId}`)
+    return response.data
+  },
+
+  async getLegalActions(gameId: string, playerId: number): Promise<LegalActions> {
+    const response = await api.get(`/games/${gameId}/actions`, { params: { player_id: playerId } })
+    return response.data
+  },
+
+  async mulligan(gameId: string, playerId: number, redraw: boolean): Promise<Game> {
+    const response = await api.post(`/games/${gameId}/mulligan`, { player_id: playerId, redraw })
+    return response.data
+  },
+
+  async playCard(gameId: string, playerId: number, instanceId: string, position?: string, targets?: string[], energy?: number[], negativeEnergy?: number[]): Promise<Game> {
+    const response = await api.post(`/games/${gameId}/play`, {
+      player_id: playerId,
+      instance_id: instanceId,
+      position,
+      targets,
+      energy,
+      negative_energy: negativeEnergy,
+    })
+    return response.data
+  },
+
+  async activateAbility(gameId: string, playerId: number, instanceId: string, ability: number, energy?: number[]): Promise<Game> {
+    const response = await api.post(`/games/${gameId}/ability`, {
+      player_id: playerId,
+      instance_id: instanceId,
+      ability,
+      energy,
+    })
+    return response.data
+  },
+
+  async attack(gameId: string, playerId: number, attackerPos: string, targetPos?: string): Promise<Game> {
+    const response = await api.post(`/games/${gameId}/attack`, {
+      player_id: playerId,
+      attacker_pos: attackerPos,
+      target_pos: targetPos,
+    })
+    return response.data
+  },
+
+  async block(gameId: string, playerId: number, blockerPos: string, attackerPos?: string): Promise<Game> {
+    const response = await api.post(`/games/${gameId}/block`, {
+      player_id: playerId,
+      blocker_pos: blockerPos,
+      attacker_pos: attackerPos,
+    })
+    return response.data
+  },
+
+  async pass(gameId: string, playerId: number): Promise<Game> {
+    const response = await api.post(`/games/${gameId}/pass`, { player_id: playerId })
+    return response.data
+  },
+
+  async changePhase(gameId: string, playerId: number): Promise<Game> {
+    const response = await api.post(`/games/${gameId}/phase`, { player_id: playerId })
+    return response.data
+  },
+
+  async resolvePhaseChoice(gameId: string, playerId: number, useNegativeEnergy: boolean, discard?: string[]): Promise<Game> {
+    const response = await api.post(`/games/${gameId}/phase/choice`, {
+      player_id: playerId,
+      use_negative_energy: useNegativeEnergy,
+      discard,
+    })
+    return response.data
+  },
+
+  async resolveEffectChoice(gameId: string, playerId: number, choiceId: string, selection: number[]): Promise<Game> {
+    const response = await api.post(`/games/${gameId}/choice`, {
+      player_id: playerId,
+      choice_id: choiceId,
+      selection,
+    })
+    return response.data
+  },
+}
+
 export default api
\ No newline at end of file
@@ -1,6 +1,7 @@
 import { defineStore } from 'pinia'
 import { ref, computed, nextTick } from 'vue'
 import type { Card, DeckCard, CardType, CardColor, CardRarity } from '@/types'
+import { BATTLE_AREA_SIZE } from '@/types'
 import { useCardStore } from '@/stores/cards'
 
 export type BattleMode = 'pvp' | 'cpu'
@@ -417,7 +418,7 @@ export const useGameStore = defineStore('game', () => {
     
     if (targetZone === 'friends') {
       console.log('Attempting to play friend card to battle area')
-      if (playerState.friends.length >= 10) {
+      if (playerState.friends.length >= BATTLE_AREA_SIZE) {
         console.log('Cannot play friend: battle area is full')
         return false
       }
@@ -1043,7 +1044,7 @@ export const useGameStore = defineStore('game', () => {
           if (!card || card.type !== 'ふれんど') continue
           
           const cost = card.cost || 1
-          if (energyUsed + cost <= availableEnergy && cpu.friends.length < 10) {
+          if (energyUsed + cost <= availableEnergy && cpu.friends.length < BATTLE_AREA_SIZE) {
             // Check if we can actually pay the color requirements
             const canAfford = canPayColorRequirements('opponent', card, energyUsed)
             
@@ -46,30 +46,216 @@ export interface Deck {
   updated_at?: string
 }
 
+export type GamePhase = 'start' | 'draw' | 'energy' | 'main' | 'end'
+export type GameStatus = 'waiting' | 'mulligan' | 'playing' | 'finished'
+
+export interface Game {
+  ID: number
+  game_id: string
+  player1_id: number
+  player2_id: number
+  current_turn: number
+  current_phase: GamePhase
+  active_player: number
+  status: GameStatus
+  winner_id?: number
+  started_at?: string
+  finished_at?: string
+  seed?: number // Sent once the game is finished
+  game_state?: GameState
+}
+
 export interface GameState {
   player1_state: PlayerState
   player2_state: PlayerState
+  shuffle_count?: number // Sent once the game is finished
+  pending_attack?: PendingAttack
+  pending_phase_choice?: PhaseChoice
+  power_modifiers?: PowerModifier[]
+  delayed_triggers?: DelayedTrigger[]
+  pending_effect?: PendingEffect
+  choice_count: number
+  ability_uses?: AbilityUse[]
+}
+
+// A once-per-turn ability that has been used
+export interface AbilityUse {
+  source_id: string
+  ability: number
+  turn: number
+}
+
+// A power change applied by a resolved effect, kept apart from the base power
+export interface PowerModifier {
+  target_id: string
+  source_id: string
+  amount: number
+  duration: EffectDuration
+  turn: number
+}
+
+export type EffectDuration = 'turn' | 'until_end_phase' | 'while_condition' | 'permanent'
+
+// An effect registered now that happens later, e.g. destroying a revived friend in the end phase
+export interface DelayedTrigger {
+  player: number
+  source_id: string
+  action: 'destroy'
+  target_id: string
+  timing: 'end_phase' | 'turn_end'
+  turn: number
+}
+
+// Effect resolution paused until a player answers a choice
+export interface PendingEffect {
+  choice: EffectChoice
+  stack: StackEntry[]
+  resume?: 'phase_step' | 'phase_end' | 'turn_end' | 'turn_start'
+}
+
+// A decision an effect asks for; the answer is a list of option indices between min and max long
+export interface EffectChoice {
+  id: string
+  player: number
+  kind: 'target' | 'option' | 'order' | 'confirm' | 'replace'
+  card_no: string
+  source_id: string
+  description: string
+  options: Target[]
+  min: number
+  max: number
+  default: number[] // Used once the deadline has passed
+  deadline: string
+}
+
+export type Zone = 'deck' | 'hand' | 'battle_area' | 'energy_area' | 'negative_energy' | 'trash' | 'field'
+
+// A card an effect can pick, by owner, zone and instance ID, or a named option
+export interface Target {
+  type: 'friend' | 'card' | 'energy' | 'negative_energy' | 'field' | 'option'
+  player?: number // Player whose zone the card is in
+  zone?: Zone
+  slot?: string // Battle area position, or index in the energy or negative energy area
+  id: string // Instance ID of a card, or the name of an option
+  card_no?: string
+  label?: string
+}
+
+export interface StackEntry {
+  card_no: string
+  ability: number
+  source_id: string
+  player: number
+  targets?: Target[] // Targets picked when the card was played
+  choices?: number[][]
+  accepted?: boolean
+  replace?: string // Friend picked to make room in a full battle area
+  cost_paid?: boolean
+  group?: StackEntry[]
+}
+
+export interface PendingAttack {
+  attacker_player: number
+  attacker_pos: string
+  target_pos?: string
+  blocker_pos?: string
+  step: 'block' | 'counter'
 }
 
+export interface LegalActions {
+  player: number
+  can_mulligan: boolean
+  phase_choice?: PhaseChoice
+  effect_choice?: EffectChoice
+  playable_cards: PlayableCard[]
+  attacks: AttackOption[]
+  abilities: AbilityOption[]
+  blockers: string[]
+  counters: PlayableCard[]
+  can_pass: boolean
+  can_end_phase: boolean
+}
+
+export interface PlayableCard {
+  instance_id: string
+  card_no: string
+  cost: number
+  energy: number[]
+  negative_energy: number[] // Suggested face-up negative energy indices to turn face down
+  positions?: string[] // Battle area positions a friend can enter
+  replace?: boolean // The battle area is full: entering a position puts the friend there in the trash
+}
+
+export interface AttackOption {
+  attacker_pos: string
+  targets: string[]
+}
+
+export interface AbilityOption {
+  position: string // Battle area position, or "field" for the field card
+  instance_id: string
+  card_no: string
+  ability: number
+  cost: AbilityCost
+  energy: number[] // Suggested energy indices to rest as payment
+}
+
+export interface AbilityCost {
+  energy?: number
+  rest?: boolean
+  flip_negative_energy?: number
+}
+
+export interface PhaseChoice {
+  player: number
+  kind: 'energy_source' | 'discard'
+  count?: number
+}
+
+// A physical card in a game; instance_id stays the same across zones
+export interface CardInstance {
+  instance_id: string
+  card_no: string
+}
+
+// Battle area positions are "0" to "9", the same slots BattleField.vue shows
+export const BATTLE_AREA_SIZE = 10
+
+export const battlePosition = (slot: number): string => String(slot)
+
 export interface PlayerState {
-  deck: string[]
-  hand: string[]
+  deck: CardInstance[] // Hidden cards have an empty instance_id and card_no until the game is finished
+  hand: CardInstance[] // The opponent's hand is hidden the same way
   battle_area: Record<string, Friend>
   energy_area: EnergyCard[]
-  negative_energy: string[]
-  trash: string[]
-  field_card?: string
+  negative_energy: NegativeEnergyCard[]
+  trash: CardInstance[]
+  field_card?: CardInstance
+  mulligan_decided: boolean
+  starting_deck?: CardInstance[] // Sent once the game is finished
 }
 
 export interface Friend {
+  instance_id: string
   card_no: string
-  power: number
+  power: number // Base power
+  effective_power: number // Power with all modifiers applied
   is_rest: boolean
   turn_played: number
 }
 
 export interface EnergyCard {
+  instance_id: string
   card_no: string
   color: CardColor
   is_rest: boolean
+}
+
+// Damage places negative energy face down; face-up cards can pay costs
+export interface NegativeEnergyCard {
+  instance_id: string
+  card_no: string
+  color: CardColor
+  face_up: boolean
+  revealed_turn?: number
 }
\ No newline at end of file